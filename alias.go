@@ -0,0 +1,115 @@
+package go_sensitive_word
+
+import (
+	"errors"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// AddAliasGroup 将多个变体（拼音、谐音、缩写等）绑定到同一个规范词，组成别名组
+// 规范词与变体都会被归一化并加入词库（记录原始写法）；
+// 匹配结果会同时给出规范词与实际匹配的变体，DelWord 删除规范词时整组删除
+func (m *Manager) AddAliasGroup(canonical string, variants ...string) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	entries := m.rawEntries(append([]string{canonical}, variants...), "")
+	if len(entries) == 0 || entries[0].Word != NormalizeWord(canonical, m.Normalizer()) {
+		return errors.New("canonical word is empty after normalization")
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Word != entries[0].Word {
+			entries[i].Canonical = entries[0].Word
+		}
+	}
+	return m.Store.AddEntries(entries)
+}
+
+// GetAliasGroup 获取词所属的别名组：规范词与全部变体（不含规范词，按字典序）
+// 词不属于任何别名组时返回词本身（归一化后）且 variants 为空，词不存在时返回空字符串
+func (m *Manager) GetAliasGroup(word string) (canonical string, variants []string) {
+	if m.Store == nil {
+		return "", nil
+	}
+	canonical = m.Store.GetCanonical(NormalizeWord(word, m.Normalizer()))
+	if canonical == "" {
+		return "", nil
+	}
+	return canonical, m.Store.GetAliases(canonical)
+}
+
+// FindAllCanonical 查找文本中的敏感词，按别名组合并后返回规范词（按首次出现顺序去重）
+// 同一概念的多个变体只报告一次
+func (m *Manager) FindAllCanonical(text string) []string {
+	words := m.FindAll(text)
+	result := make([]string, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		canonical, _ := m.matchAlias(word)
+		if seen[canonical] {
+			continue
+		}
+		seen[canonical] = true
+		result = append(result, canonical)
+	}
+	return result
+}
+
+// matchAlias 返回匹配文本对应的规范词与词库中的变体（均为归一化后的词）
+func (m *Manager) matchAlias(word string) (canonical, variant string) {
+	variant = NormalizeWord(word, m.Normalizer())
+	if m.Store != nil {
+		canonical = m.Store.GetCanonical(variant)
+	}
+	if canonical == "" {
+		canonical = variant
+	}
+	return canonical, variant
+}
+
+// matchResult 构造包含来源、原始写法与别名组信息的匹配结果
+func (m *Manager) matchResult(word string) filter.MatchResult {
+	return m.matchResultWith(word, m.Normalizer())
+}
+
+// matchResultWith 按指定归一化配置构造匹配结果（快照使用创建时的配置）
+func (m *Manager) matchResultWith(word string, cfg NormalizerConfig) filter.MatchResult {
+	variant := NormalizeWord(word, cfg)
+	res := filter.MatchResult{Word: word, Original: variant, Canonical: variant, Variant: variant}
+	if m.Store == nil {
+		return res
+	}
+	res.Source = m.Store.GetWordSources(variant)
+	if originals := m.Store.GetOriginals(variant); len(originals) > 0 {
+		res.Original = originals[0].Text
+	}
+	if canonical := m.Store.GetCanonical(variant); canonical != "" {
+		res.Canonical = canonical
+	}
+	return res
+}
+
+// findAllWithSource 使用 f 查找敏感词并按首次出现去重构造匹配结果
+func findAllWithSource(f filter.Filter, text string, resolve func(word string) filter.MatchResult) []filter.MatchResult {
+	words := f.FindAll(text)
+	result := make([]filter.MatchResult, 0, len(words))
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		result = append(result, resolve(word))
+	}
+	return result
+}
+
+// findAllCountWithSource 使用 f 统计敏感词出现次数并构造匹配结果
+func findAllCountWithSource(f filter.Filter, text string, resolve func(word string) filter.MatchResult) map[string]filter.MatchResult {
+	countMap := f.FindAllCount(text)
+	result := make(map[string]filter.MatchResult, len(countMap))
+	for word := range countMap {
+		result[word] = resolve(word)
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// newAliasManager 创建包含别名组 赌博/dubo/堵博 与普通词 其他 的 Manager
+func newAliasManager(t *testing.T) *Manager {
+	t.Helper()
+	m := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := m.AddAliasGroup("赌博", "DuBo", "堵博"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddWord("其他"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestAliasGroup(t *testing.T) {
+	m := newAliasManager(t)
+	canonical, variants := m.GetAliasGroup("堵博")
+	if canonical != "赌博" || !reflect.DeepEqual(variants, []string{"dubo", "堵博"}) {
+		t.Fatalf("alias group: %q %v", canonical, variants)
+	}
+}
+
+func TestAliasGroup_Match(t *testing.T) {
+	m := newAliasManager(t)
+	syncTestFilter(t, m)
+	if got := m.FindAllCanonical("来dubo，来堵博"); !reflect.DeepEqual(got, []string{"赌博"}) {
+		t.Fatalf("canonical matches: %v", got)
+	}
+	results := m.FindAllWithSource("来DUBO")
+	if len(results) != 1 || results[0].Canonical != "赌博" || results[0].Variant != "dubo" {
+		t.Fatalf("match results: %+v", results)
+	}
+}
+
+func TestAliasGroup_ExportImport(t *testing.T) {
+	m := newAliasManager(t)
+	// 别名组经导出导入后保留
+	var buf bytes.Buffer
+	if err := m.Export(&buf, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	dst := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := dst.Import(&buf, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	if canonical, variants := dst.GetAliasGroup("dubo"); canonical != "赌博" || len(variants) != 2 {
+		t.Fatalf("imported alias group: %q %v", canonical, variants)
+	}
+}
+
+func TestAliasGroup_Delete(t *testing.T) {
+	m := newAliasManager(t)
+	// 删除变体只删除该变体，删除规范词时整组删除
+	if err := m.DelWord("堵博"); err != nil {
+		t.Fatal(err)
+	}
+	if _, variants := m.GetAliasGroup("赌博"); !reflect.DeepEqual(variants, []string{"dubo"}) {
+		t.Fatalf("after deleting variant: %v", variants)
+	}
+	if err := m.DelWord("赌博"); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Store.ReadString(); !reflect.DeepEqual(got, []string{"其他"}) {
+		t.Fatalf("after deleting canonical: %v", got)
+	}
+}
@@ -0,0 +1,129 @@
+package go_sensitive_word
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/ac"
+)
+
+// allowList 白名单：命中区间完全落在白名单短语出现位置内的敏感词会被忽略
+// 例如白名单包含 "Essex" 时，"Essex" 中的 "sex" 不再视为命中
+type allowList struct {
+	words   []string    // 归一化后的白名单短语（排序去重）
+	matcher *ac.ACModel // 白名单短语的匹配自动机
+}
+
+// newAllowList 使用归一化后的短语构建白名单
+func newAllowList(words []string) *allowList {
+	set := make(map[string]struct{}, len(words))
+	list := make([]string, 0, len(words))
+	for _, w := range words {
+		if w == "" {
+			continue
+		}
+		if _, ok := set[w]; ok {
+			continue
+		}
+		set[w] = struct{}{}
+		list = append(list, w)
+	}
+	sort.Strings(list)
+	a := &allowList{words: list}
+	if len(list) > 0 {
+		a.matcher = ac.NewACModel()
+		a.matcher.Rebuild(list)
+	}
+	return a
+}
+
+func (a *allowList) empty() bool {
+	return a == nil || len(a.words) == 0
+}
+
+// filter 剔除被白名单短语覆盖的命中区间（区间均基于规范化文本）
+func (a *allowList) filter(normText string, ranges []filter.Range) []filter.Range {
+	if a.empty() {
+		return ranges
+	}
+	allowed := a.matcher.FindAllRanges(normText)
+	if len(allowed) == 0 {
+		return ranges
+	}
+	res := ranges[:0:0]
+	for _, r := range ranges {
+		covered := false
+		for _, w := range allowed {
+			if w.Start <= r.Start && r.End <= w.End {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// addAllowWords 归一化后添加白名单短语并整体切换，调用方需串行化修改
+func (nf *normalizedFilter) addAllowWords(words []string) {
+	cfg := nf.config()
+	list := append([]string{}, nf.allow.Load().words...)
+	for _, word := range words {
+		if normalized := NormalizeWord(word, cfg); normalized != "" {
+			list = append(list, normalized)
+		}
+	}
+	nf.allow.Store(newAllowList(list))
+}
+
+// delAllowWords 归一化后删除白名单短语并整体切换，调用方需串行化修改
+func (nf *normalizedFilter) delAllowWords(words []string) {
+	cfg := nf.config()
+	del := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		del[NormalizeWord(word, cfg)] = struct{}{}
+	}
+	current := nf.allow.Load().words
+	list := make([]string, 0, len(current))
+	for _, w := range current {
+		if _, ok := del[w]; !ok {
+			list = append(list, w)
+		}
+	}
+	nf.allow.Store(newAllowList(list))
+}
+
+// AddAllowWords 添加白名单短语（支持多个）
+// 文本中出现在白名单短语内部的敏感词不会被检测、替换或删除
+// 注意：短语会被归一化后再保存，确保与测试文本的归一化策略一致
+func (m *Manager) AddAllowWords(words ...string) error {
+	if m.nf == nil {
+		return errors.New("filter is nil")
+	}
+	m.allowMu.Lock()
+	defer m.allowMu.Unlock()
+	m.nf.addAllowWords(words)
+	return nil
+}
+
+// DelAllowWords 删除白名单短语（支持多个）
+func (m *Manager) DelAllowWords(words ...string) error {
+	if m.nf == nil {
+		return errors.New("filter is nil")
+	}
+	m.allowMu.Lock()
+	defer m.allowMu.Unlock()
+	m.nf.delAllowWords(words)
+	return nil
+}
+
+// GetAllowWords 获取全部白名单短语（归一化后，按字典序排序）
+func (m *Manager) GetAllowWords() []string {
+	if m.nf == nil {
+		return nil
+	}
+	return append([]string{}, m.nf.allow.Load().words...)
+}
@@ -0,0 +1,22 @@
+package go_sensitive_word
+
+import "testing"
+
+func TestAllowWords(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "sex")
+	if err := m.AddAllowWords("Essex"); err != nil {
+		t.Fatal(err)
+	}
+	if m.IsSensitive("Welcome to ESSEX") {
+		t.Fatal("allowlisted phrase should not be sensitive")
+	}
+	if got := m.Replace("Essex sex", '*'); got != "Essex ***" {
+		t.Fatalf("unexpected replace result %q", got)
+	}
+	if err := m.DelAllowWords("essex"); err != nil {
+		t.Fatal(err)
+	}
+	if !m.IsSensitive("Essex") {
+		t.Fatal("expect sensitive after allow word removed")
+	}
+}
@@ -0,0 +1,131 @@
+package go_sensitive_word
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// 备份文件格式标识与版本
+const (
+	backupFormat        = "go-sensitive-word/backup"
+	backupFormatVersion = 1
+)
+
+// backupArchive 备份文件内容（自描述 JSON）
+type backupArchive struct {
+	Format        string           `json:"format"`         // 格式标识，固定为 backupFormat
+	FormatVersion int              `json:"format_version"` // 备份格式版本
+	CreatedAt     time.Time        `json:"created_at"`     // 备份时间
+	Version       uint64           `json:"version"`        // 词库版本号
+	Normalizer    NormalizerConfig `json:"normalizer"`     // 归一化配置
+	Entries       []DictEntry      `json:"entries"`        // 词条（含来源与元数据）
+	AllowWords    []string         `json:"allow_words"`    // 白名单短语
+}
+
+// Backup 将 Manager 的完整状态写入备份
+// 包含：词条（来源、元数据、原始写法、别名组）、归一化配置、白名单、词库版本号
+func (m *Manager) Backup(w io.Writer) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	archive := backupArchive{
+		Format:        backupFormat,
+		FormatVersion: backupFormatVersion,
+		CreatedAt:     time.Now(),
+		Version:       m.Store.GetStats().Version,
+		Normalizer:    m.Normalizer(),
+		Entries:       m.Store.GetEntries(),
+		AllowWords:    m.GetAllowWords(),
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(archive)
+}
+
+// Restore 从备份恢复 Manager 的完整状态
+// 恢复是原子的：备份会先完整解析和校验（含资源限制），任何错误都不会修改当前状态；
+// 校验通过后词库、匹配结构、归一化配置与白名单整体切换，版本号取备份版本与当前版本 + 1 中的较大值
+func (m *Manager) Restore(r io.Reader) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	archive, err := readBackup(r)
+	if err != nil {
+		return err
+	}
+
+	// 按备份中的归一化配置重新归一化，保证词库与配置一致
+	cfg := archive.Normalizer
+	entries := make([]DictEntry, 0, len(archive.Entries))
+	for _, entry := range archive.Entries {
+		word := NormalizeWord(entry.Word, cfg)
+		if word == "" {
+			return fmt.Errorf("backup entry %q is empty after normalization", entry.Word)
+		}
+		entry.Word = word
+		if entry.Canonical != "" {
+			entry.Canonical = NormalizeWord(entry.Canonical, cfg)
+		}
+		entries = append(entries, entry)
+	}
+	allowWords := make([]string, 0, len(archive.AllowWords))
+	for _, word := range archive.AllowWords {
+		allowWords = append(allowWords, NormalizeWord(word, cfg))
+	}
+	allow := newAllowList(allowWords)
+
+	// 词库、匹配结构与配置在词库写锁内一起切换，期间的增删会等待切换完成后作用于新词库
+	m.restoreMu.Lock()
+	defer m.restoreMu.Unlock()
+	m.allowMu.Lock()
+	defer m.allowMu.Unlock()
+	return m.replaceState(entries, cfg, allow, archive.Version, nil)
+}
+
+// replaceState 在词库写锁内整体切换词库、匹配结构、归一化配置与白名单，调用方需持有 restoreMu 与 allowMu
+// 限制检查在修改任何状态之前完成；load 不为 nil 时用于直接恢复匹配结构，否则按新词表重建
+// 重建前先对监听协程设置栅栏，切换前已排队的增删通知不会叠加到新的匹配结构上；
+// 设置栅栏后重建不能失败，load 失败时同样按新词表重建
+func (m *Manager) replaceState(entries []DictEntry, cfg NormalizerConfig, allow *allowList, version uint64, load func() error) error {
+	return m.Store.ResetEntries(entries, version, func(words []string) (bool, error) {
+		rebuilt := true
+		if rb, ok := m.nf.inner.(filter.Rebuilder); ok {
+			if f, ok := m.nf.inner.(filter.Fencer); ok {
+				f.Fence()
+			}
+			if load == nil || load() != nil {
+				rb.Rebuild(words)
+			}
+		} else if load != nil {
+			if err := load(); err != nil {
+				return false, err
+			}
+		} else {
+			rebuilt = false
+		}
+		m.nf.cfg.Store(&cfg)
+		m.nf.allow.Store(allow)
+		return rebuilt, nil
+	})
+}
+
+// readBackup 读取并校验备份文件
+func readBackup(r io.Reader) (*backupArchive, error) {
+	var archive backupArchive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("decode backup: %w", err)
+	}
+	if archive.Format != backupFormat {
+		return nil, fmt.Errorf("invalid backup format %q", archive.Format)
+	}
+	if archive.FormatVersion < 1 || archive.FormatVersion > backupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", archive.FormatVersion)
+	}
+	return &archive, nil
+}
@@ -0,0 +1,147 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBackupRestore(t *testing.T) {
+	src := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := src.AddWordsWithSource([]string{"sex", "违禁词"}, "custom"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddEntries([]DictEntry{{Word: "违禁词", Metadata: map[string]string{"level": "high"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddAllowWords("Essex"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.Backup(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := newTestManager(t, FilterOption{Type: FilterDfa}, "旧词")
+	if err := dst.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(src.GetEntries(), dst.GetEntries()) {
+		t.Fatalf("entries mismatch:\n%v\n%v", src.GetEntries(), dst.GetEntries())
+	}
+	if got := dst.GetAllowWords(); !reflect.DeepEqual(got, []string{"essex"}) {
+		t.Fatalf("allow words mismatch: %v", got)
+	}
+	if src.GetStats().Version != dst.GetStats().Version {
+		t.Fatalf("version mismatch: %d != %d", src.GetStats().Version, dst.GetStats().Version)
+	}
+	// 恢复后立即生效，且白名单同样生效
+	if got := dst.FindAll("旧词 违禁词 Essex sex"); !reflect.DeepEqual(got, []string{"违禁词", "sex"}) {
+		t.Fatalf("unexpected matches after restore: %v", got)
+	}
+}
+
+func TestRestore_InvalidArchiveKeepsState(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "违禁词")
+	before := m.GetEntries()
+	for _, archive := range []string{
+		`{"format":"other","format_version":1}`,
+		`{"format":"go-sensitive-word/backup","format_version":99}`,
+		`{"format":"go-sensitive-word/backup","format_version":1,"entries":[{"word":"ok"},`,
+	} {
+		if err := m.Restore(strings.NewReader(archive)); err == nil {
+			t.Fatalf("expect error for archive %s", archive)
+		}
+	}
+	if !reflect.DeepEqual(before, m.GetEntries()) {
+		t.Fatalf("state changed after failed restore: %v", m.GetEntries())
+	}
+}
+
+// testBackup 返回包含 words 的备份内容
+func testBackup(t *testing.T, words ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := newTestManager(t, FilterOption{Type: FilterAC}, words...).Backup(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestRestore_LimitRejected(t *testing.T) {
+	backup := testBackup(t, "赌博", "毒品")
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "旧词", "枪支", "弹药")
+		// 超出词库上限时整体拒绝，词库与匹配结构均保持不变
+		m.SetLimits(Limits{MaxDictWords: 1})
+		var limitErr *LimitError
+		if err := m.Restore(bytes.NewReader(backup)); !errors.As(err, &limitErr) || limitErr.Kind != LimitDictWords {
+			t.Fatalf("restore limit error: %v", err)
+		}
+		if m.GetStats().TotalWords != 3 || !m.IsSensitive("旧词") || m.IsSensitive("赌博") {
+			t.Fatal("state changed after rejected restore")
+		}
+	})
+}
+
+func TestRestore_VersionIncreases(t *testing.T) {
+	backup := testBackup(t, "赌博", "毒品")
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "旧词", "枪支", "弹药")
+		if err := m.DelWord("弹药"); err != nil {
+			t.Fatal(err)
+		}
+		// 恢复较旧的备份时版本号仍然递增
+		before := m.GetStats().Version
+		if before <= 1 {
+			t.Fatalf("backup is not older than the live dictionary: version %d", before)
+		}
+		if err := m.Restore(bytes.NewReader(backup)); err != nil {
+			t.Fatal(err)
+		}
+		if got := m.GetStats().Version; got <= before {
+			t.Fatalf("version went back: %d -> %d", before, got)
+		}
+		if !m.IsSensitive("赌博") || m.IsSensitive("旧词") {
+			t.Fatal("filter not switched with store")
+		}
+	})
+}
+
+func TestRestore_DropsQueuedUpdates(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft})
+		// 经公开 API 写入，由后台监听协程同步到过滤器
+		if err := m.AddWord("base"); err != nil {
+			t.Fatal(err)
+		}
+		waitForSensitive(t, m, "base")
+		var buf bytes.Buffer
+		if err := m.Backup(&buf); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 5; i++ {
+			// 恢复前已排队、尚未应用的增删不能叠加到恢复后的过滤器上
+			if err := m.AddWord("foo"); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.DelWord("base"); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.Restore(bytes.NewReader(buf.Bytes())); err != nil {
+				t.Fatal(err)
+			}
+			waitForListener(t, m)
+			if _, ok := m.GetEntry("foo"); ok || m.IsSensitive("foo") {
+				t.Fatalf("round %d: stale add applied after restore", i)
+			}
+			if _, ok := m.GetEntry("base"); !ok || !m.IsSensitive("base") {
+				t.Fatalf("round %d: stale delete applied after restore", i)
+			}
+		}
+	})
+}
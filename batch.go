@@ -0,0 +1,169 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchOptions 批量检测配置
+type BatchOptions struct {
+	Workers int // 并发数，<= 0 时为 runtime.GOMAXPROCS(0)
+}
+
+func (o BatchOptions) workers() int {
+	if o.Workers <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return o.Workers
+}
+
+// BatchResult 流式批量检测中单条文本的结果
+type BatchResult struct {
+	Index int      // 文本在输入中的序号（从 0 开始）
+	Words []string // 命中的敏感词（同 FindAll）
+}
+
+// FindAllBatch 使用有界协程池批量执行 FindAll，结果与 texts 一一对应
+// ctx 取消时尽快停止（单条长文本的匹配也会中断），返回已完成的部分结果（未处理的位置为 nil）与 ctx.Err()
+func (m *Manager) FindAllBatch(ctx context.Context, texts []string, opts BatchOptions) ([][]string, error) {
+	results := make([][]string, len(texts))
+	err := runBatch(ctx, len(texts), opts.workers(), func(i int) error {
+		var err error
+		results[i], err = m.FindAllContext(ctx, texts[i])
+		// 输入截断与命中封顶不视为失败
+		var limitErr *LimitError
+		if errors.As(err, &limitErr) {
+			return nil
+		}
+		return err
+	})
+	return results, err
+}
+
+// FindAllStream 流式批量执行 FindAll：从 texts 读取文本，按输入顺序输出结果
+// 同时处理中的文本不超过并发数的 3 倍，输出未被读取时暂停读取输入
+// texts 关闭且全部结果输出后关闭返回的通道；ctx 取消时尽快停止并关闭通道，调用方通过 ctx.Err() 区分
+// 调用方需读完返回的通道或取消 ctx，否则内部协程无法退出
+func (m *Manager) FindAllStream(ctx context.Context, texts <-chan string, opts BatchOptions) <-chan BatchResult {
+	workers := opts.workers()
+	out := make(chan BatchResult, workers)
+	jobs := make(chan batchJob)
+	pending := make(chan chan BatchResult, workers*2) // 按输入顺序排队等待输出的结果
+
+	// 分发：为每条文本登记结果槽位后交给工作协程
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for i := 0; ; i++ {
+			var text string
+			select {
+			case <-ctx.Done():
+				return
+			case t, ok := <-texts:
+				if !ok {
+					return
+				}
+				text = t
+			}
+			done := make(chan BatchResult, 1)
+			select {
+			case pending <- done:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- batchJob{index: i, text: text, done: done}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				// 取消后的部分结果由汇总协程丢弃
+				words, _ := m.FindAllContext(ctx, job.text)
+				job.done <- BatchResult{Index: job.index, Words: words}
+			}
+		}()
+	}
+
+	// 汇总：按登记顺序等待结果并输出
+	go func() {
+		defer close(out)
+		defer wg.Wait()
+		for done := range pending {
+			var res BatchResult
+			select {
+			case res = <-done:
+			case <-ctx.Done():
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case out <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+type batchJob struct {
+	index int
+	text  string
+	done  chan BatchResult
+}
+
+// runBatch 使用最多 workers 个协程执行 fn(0) ... fn(n-1)
+// ctx 取消或 fn 返回错误时尽快停止（不再领取新的序号），返回首个错误
+func runBatch(ctx context.Context, n, workers int, fn func(i int) error) error {
+	if workers > n {
+		workers = n
+	}
+	var (
+		next  atomic.Int64
+		stop  atomic.Bool
+		once  sync.Once
+		first error
+		wg    sync.WaitGroup
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			first = err
+			stop.Store(true)
+		})
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				if err := ctx.Err(); err != nil {
+					fail(err)
+					return
+				}
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := fn(i); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return first
+}
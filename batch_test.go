@@ -0,0 +1,97 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// newBatchFixture 返回批量匹配测试的 Manager、输入文本及逐条 FindAll 的结果
+func newBatchFixture(t *testing.T) (*Manager, []string, [][]string) {
+	t.Helper()
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "赌博", "毒品", "SEX")
+	texts := make([]string, 1000)
+	for i := range texts {
+		switch i % 4 {
+		case 0:
+			texts[i] = fmt.Sprintf("%d 号在赌博", i)
+		case 1:
+			texts[i] = fmt.Sprintf("%d 号贩卖毒品和ＳＥＸ", i)
+		default:
+			texts[i] = fmt.Sprintf("正常消息 %d", i)
+		}
+	}
+	want := make([][]string, len(texts))
+	for i, text := range texts {
+		want[i] = m.FindAll(text)
+	}
+	return m, texts, want
+}
+
+func TestFindAllBatch(t *testing.T) {
+	m, texts, want := newBatchFixture(t)
+	got, err := m.FindAllBatch(context.Background(), texts, BatchOptions{Workers: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatal("batch results differ from FindAll")
+	}
+}
+
+func TestFindAllBatch_Canceled(t *testing.T) {
+	m, texts, _ := newBatchFixture(t)
+	// 取消后返回 ctx.Err()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.FindAllBatch(ctx, texts, BatchOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled batch error: %v", err)
+	}
+}
+
+func TestFindAllStream(t *testing.T) {
+	m, texts, want := newBatchFixture(t)
+	// 按输入顺序输出
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, text := range texts {
+			in <- text
+		}
+	}()
+	i := 0
+	for res := range m.FindAllStream(context.Background(), in, BatchOptions{Workers: 4}) {
+		if res.Index != i || !reflect.DeepEqual(res.Words, want[i]) {
+			t.Fatalf("stream result %d: %+v, want %v", i, res, want[i])
+		}
+		i++
+	}
+	if i != len(texts) {
+		t.Fatalf("stream produced %d results, want %d", i, len(texts))
+	}
+}
+
+func TestFindAllStream_Canceled(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "赌博")
+	// 输入未关闭时取消，输出通道也会关闭
+	ctx, cancel := context.WithCancel(context.Background())
+	endless := make(chan string)
+	go func() {
+		for {
+			select {
+			case endless <- "赌博":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	out := m.FindAllStream(ctx, endless, BatchOptions{Workers: 2})
+	for n := 0; n < 10; n++ {
+		<-out
+	}
+	cancel()
+	for range out {
+	}
+}
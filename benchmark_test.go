@@ -1,8 +1,16 @@
 package go_sensitive_word
 
 import (
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/ac"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/dat"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/dfa"
 )
 
 // 性能测试：DFA 算法
@@ -342,3 +350,327 @@ func BenchmarkDFA_LongText(b *testing.B) {
 		_ = filter.IsSensitive(longText)
 	}
 }
+
+// 性能测试：双数组 AC 自动机
+func BenchmarkDAT_IsSensitive(b *testing.B) {
+	filter, _ := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDAT},
+	)
+
+	filter.LoadDictEmbed(
+		DictReactionary,
+		DictAdvertisement,
+		DictPolitical,
+		DictViolence,
+		DictPeopleLife,
+		DictGunExplosion,
+		DictPornography,
+		DictCorruption,
+	)
+
+	time.Sleep(200 * time.Millisecond)
+
+	testText := "这是一个测试文本包含多个敏感词台湾国毒品销售违禁内容"
+	_ = filter.IsSensitive(testText)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filter.IsSensitive(testText)
+	}
+}
+
+func BenchmarkDAT_FindAll(b *testing.B) {
+	filter, _ := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDAT},
+	)
+
+	filter.LoadDictEmbed(
+		DictReactionary,
+		DictAdvertisement,
+		DictPolitical,
+		DictViolence,
+		DictPeopleLife,
+		DictGunExplosion,
+		DictPornography,
+		DictCorruption,
+	)
+
+	time.Sleep(200 * time.Millisecond)
+
+	testText := "这是一个测试文本包含多个敏感词台湾国毒品销售违禁内容"
+	_ = filter.FindAll(testText)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filter.FindAll(testText)
+	}
+}
+
+func BenchmarkDAT_Replace(b *testing.B) {
+	filter, _ := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDAT},
+	)
+
+	filter.LoadDictEmbed(
+		DictReactionary,
+		DictAdvertisement,
+		DictPolitical,
+		DictViolence,
+		DictPeopleLife,
+		DictGunExplosion,
+		DictPornography,
+		DictCorruption,
+	)
+
+	time.Sleep(200 * time.Millisecond)
+
+	testText := "这是一个测试文本包含多个敏感词台湾国毒品销售违禁内容"
+	_ = filter.Replace(testText, '*')
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filter.Replace(testText, '*')
+	}
+}
+
+func BenchmarkDAT_LongText(b *testing.B) {
+	filter, _ := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterDAT},
+	)
+
+	filter.LoadDictEmbed(
+		DictReactionary,
+		DictAdvertisement,
+		DictPolitical,
+		DictViolence,
+		DictPeopleLife,
+		DictGunExplosion,
+		DictPornography,
+		DictCorruption,
+	)
+
+	time.Sleep(200 * time.Millisecond)
+
+	longText := ""
+	for i := 0; i < 500; i++ {
+		longText += "这是一个测试文本包含多个敏感词台湾国毒品销售违禁内容"
+	}
+
+	_ = filter.IsSensitive(longText)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filter.IsSensitive(longText)
+	}
+}
+
+// benchmarkWords 返回全部内置词库归一化后的词
+func benchmarkWords() []string {
+	var words []string
+	for _, dict := range []string{
+		DictReactionary, DictAdvertisement, DictPolitical, DictViolence,
+		DictPeopleLife, DictGunExplosion, DictPornography, DictCorruption,
+	} {
+		for _, line := range strings.Split(dict, "\n") {
+			if w := NormalizeWord(line, DefaultNormalizer()); w != "" {
+				words = append(words, w)
+			}
+		}
+	}
+	return words
+}
+
+// benchmarkAutomata 直接构建各算法的匹配结构（不经过归一化包装与存储）
+func benchmarkAutomata() map[string]func(words []string) filter.RangedFilter {
+	return map[string]func(words []string) filter.RangedFilter{
+		"DFA": func(words []string) filter.RangedFilter {
+			m := dfa.NewDFAModel()
+			m.Rebuild(words)
+			return m
+		},
+		"AC": func(words []string) filter.RangedFilter {
+			m := ac.NewACModel()
+			m.Rebuild(words)
+			return m
+		},
+		"DAT": func(words []string) filter.RangedFilter {
+			m := dat.NewDATModel()
+			m.Rebuild(words)
+			return m
+		},
+	}
+}
+
+// 内存对比：构建全部内置词库后常驻堆内存（heap-bytes）
+func BenchmarkAutomaton_Memory(b *testing.B) {
+	words := benchmarkWords()
+	for _, name := range []string{"DFA", "AC", "DAT"} {
+		newAutomaton := benchmarkAutomata()[name]
+		b.Run(name, func(b *testing.B) {
+			var total uint64
+			for i := 0; i < b.N; i++ {
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				m := newAutomaton(words)
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				runtime.KeepAlive(m)
+				if after.HeapAlloc > before.HeapAlloc {
+					total += after.HeapAlloc - before.HeapAlloc
+				}
+			}
+			b.ReportMetric(float64(total)/float64(b.N), "heap-bytes")
+		})
+	}
+}
+
+// 吞吐量对比：长文本全量匹配（MB/s）
+func BenchmarkAutomaton_Throughput(b *testing.B) {
+	words := benchmarkWords()
+	text := strings.Repeat("这是一个测试文本包含多个敏感词台湾国毒品销售违禁内容 normal english text ", 500)
+	for _, name := range []string{"DFA", "AC", "DAT"} {
+		m := benchmarkAutomata()[name](words)
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(text)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = m.FindAllRanges(text)
+			}
+		})
+	}
+}
+
+// 字节切片 API：未命中路径（网关扫描请求体的常见情况）
+func BenchmarkAC_IsSensitiveBytes(b *testing.B) {
+	filter, _ := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterAC},
+	)
+
+	filter.LoadDictEmbed(
+		DictReactionary,
+		DictAdvertisement,
+		DictPolitical,
+		DictViolence,
+		DictPeopleLife,
+		DictGunExplosion,
+		DictPornography,
+		DictCorruption,
+	)
+
+	time.Sleep(100 * time.Millisecond)
+
+	body := []byte(strings.Repeat(`{"user":"alice","message":"今天天气不错，我们去公园散步吧"}`, 20))
+	_ = filter.IsSensitiveBytes(body)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filter.IsSensitiveBytes(body)
+	}
+}
+
+func BenchmarkAC_IsSensitiveString(b *testing.B) {
+	filter, _ := NewFilter(
+		StoreOption{Type: StoreMemory},
+		FilterOption{Type: FilterAC},
+	)
+
+	filter.LoadDictEmbed(
+		DictReactionary,
+		DictAdvertisement,
+		DictPolitical,
+		DictViolence,
+		DictPeopleLife,
+		DictGunExplosion,
+		DictPornography,
+		DictCorruption,
+	)
+
+	time.Sleep(100 * time.Millisecond)
+
+	body := strings.Repeat(`{"user":"alice","message":"今天天气不错，我们去公园散步吧"}`, 20)
+	_ = filter.IsSensitive(body)
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filter.IsSensitive(body)
+	}
+}
+
+// 预过滤器：无敏感词文本（大部分流量）
+func BenchmarkAC_IsSensitive_Prefilter(b *testing.B) {
+	for _, prefilter := range []bool{false, true} {
+		filter, _ := NewFilter(
+			StoreOption{Type: StoreMemory},
+			FilterOption{Type: FilterAC, Prefilter: prefilter},
+		)
+
+		filter.LoadDictEmbed(
+			DictReactionary,
+			DictAdvertisement,
+			DictPolitical,
+			DictViolence,
+			DictPeopleLife,
+			DictGunExplosion,
+			DictPornography,
+			DictCorruption,
+		)
+
+		time.Sleep(100 * time.Millisecond)
+
+		body := strings.Repeat("今天天气不错，我们去公园散步吧。", 20)
+		_ = filter.IsSensitive(body)
+
+		b.Run(fmt.Sprintf("prefilter=%v", prefilter), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(body)))
+			for i := 0; i < b.N; i++ {
+				_ = filter.IsSensitive(body)
+			}
+		})
+		filter.Close()
+	}
+}
+
+func BenchmarkAC_FindAll_Parallel(b *testing.B) {
+	for _, workers := range []int{1, 4} {
+		filter, _ := NewFilter(
+			StoreOption{Type: StoreMemory},
+			FilterOption{Type: FilterAC, Parallel: ParallelOptions{Workers: workers}},
+		)
+
+		filter.LoadDictEmbed(
+			DictReactionary,
+			DictAdvertisement,
+			DictPolitical,
+			DictViolence,
+			DictPeopleLife,
+			DictGunExplosion,
+			DictPornography,
+			DictCorruption,
+		)
+
+		time.Sleep(100 * time.Millisecond)
+
+		body := strings.Repeat("今天天气不错，我们去公园散步吧。这里有人在赌博。", 20000)
+		_ = filter.FindAll(body)
+
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(body)))
+			for i := 0; i < b.N; i++ {
+				_ = filter.FindAll(body)
+			}
+		})
+		filter.Close()
+	}
+}
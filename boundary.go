@@ -0,0 +1,142 @@
+package go_sensitive_word
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BoundaryMode 词边界规则：决定敏感词是否只在词边界处命中（如 "ass" 不命中 "class"）
+// 边界按原文判断：命中片段前后相邻的原文字符
+type BoundaryMode uint32
+
+const (
+	// BoundaryNone 子串匹配，不检查词边界（默认）
+	BoundaryNone BoundaryMode = iota
+	// BoundaryAuto 按文字自动判断：词首（尾）为拉丁、希腊、西里尔等按空格分词的字母时，
+	// 前（后）一个原文字符不能是这类字母；CJK 一端不检查，保持子串匹配
+	BoundaryAuto
+	// BoundaryWord 两端都要求词边界：前后相邻的原文字符都不能是字母（含 CJK）
+	BoundaryWord
+)
+
+// MetaBoundary 词条元数据中按词设置边界规则的键，值为 "none"、"auto" 或 "word"，优先于全局规则
+const MetaBoundary = "boundary"
+
+// String 返回边界规则名称（即元数据中使用的值）
+func (b BoundaryMode) String() string {
+	switch b {
+	case BoundaryNone:
+		return "none"
+	case BoundaryAuto:
+		return "auto"
+	case BoundaryWord:
+		return "word"
+	}
+	return "unknown"
+}
+
+// ParseBoundaryMode 解析边界规则名称
+func ParseBoundaryMode(s string) (BoundaryMode, error) {
+	switch s {
+	case "none":
+		return BoundaryNone, nil
+	case "auto":
+		return BoundaryAuto, nil
+	case "word":
+		return BoundaryWord, nil
+	}
+	return BoundaryNone, fmt.Errorf("invalid boundary mode %q", s)
+}
+
+// SetWordBoundary 为词设置边界规则（写入词条元数据 MetaBoundary），词不存在时一并添加
+func (m *Manager) SetWordBoundary(mode BoundaryMode, words ...string) error {
+	if mode > BoundaryWord {
+		return fmt.Errorf("invalid boundary mode %d", mode)
+	}
+	entries := make([]DictEntry, 0, len(words))
+	for _, word := range words {
+		entries = append(entries, DictEntry{Word: word, Metadata: map[string]string{MetaBoundary: mode.String()}})
+	}
+	return m.AddEntries(entries)
+}
+
+// WordBoundary 返回词当前生效的边界规则（按词规则优先，否则为全局规则）
+func (m *Manager) WordBoundary(word string) BoundaryMode {
+	return m.nf.boundaryOf(NormalizeWord(word, m.Normalizer()))
+}
+
+// boundaryRules 按词边界规则的来源（词库存储的词条元数据）
+type boundaryRules interface {
+	HasMetadataKey(key string) bool
+	GetMetadata(word, key string) (string, bool)
+}
+
+// boundaryActive 判断是否需要检查词边界（设置了全局规则或存在按词规则）
+func (nf *normalizedFilter) boundaryActive() bool {
+	return nf.bound != BoundaryNone || nf.rules != nil && nf.rules.HasMetadataKey(MetaBoundary)
+}
+
+// boundaryOf 返回规范化词生效的边界规则，没有任何词设置按词规则时不查询词条元数据
+func (nf *normalizedFilter) boundaryOf(word string) BoundaryMode {
+	if nf.rules == nil || !nf.rules.HasMetadataKey(MetaBoundary) {
+		return nf.bound
+	}
+	if v, ok := nf.rules.GetMetadata(word, MetaBoundary); ok {
+		if mode, err := ParseBoundaryMode(v); err == nil {
+			return mode
+		}
+	}
+	return nf.bound
+}
+
+// bounded 判断规范化词 word 的一次命中是否满足边界规则，before、after 为命中前后相邻的原文字符（-1 表示文本边缘）
+func (nf *normalizedFilter) bounded(word string, before, after rune) bool {
+	mode := nf.boundaryOf(word)
+	if mode == BoundaryNone {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(word)
+	last, _ := utf8.DecodeLastRuneInString(word)
+	return boundaryOK(mode, first, before) && boundaryOK(mode, last, after)
+}
+
+// boundaryOK 判断词端字符 edge 与相邻原文字符 next 之间是否满足边界规则
+func boundaryOK(mode BoundaryMode, edge, next rune) bool {
+	if next < 0 {
+		return true
+	}
+	switch mode {
+	case BoundaryAuto:
+		return !spacedLetter(edge) || !spacedLetter(next)
+	case BoundaryWord:
+		return !unicode.IsLetter(next) && !unicode.IsMark(next)
+	}
+	return true
+}
+
+// spacedLetter 判断是否为按空格分词的文字（拉丁、希腊、西里尔等）的字母；CJK 字符返回 false
+func spacedLetter(r rune) bool {
+	if !unicode.IsLetter(r) && !unicode.IsMark(r) {
+		return false
+	}
+	return !unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// runeBefore 返回 text 中偏移 i 之前的字符，i 为 0 时返回 -1
+func runeBefore(text string, i int) rune {
+	if i <= 0 {
+		return -1
+	}
+	r, _ := utf8.DecodeLastRuneInString(text[:i])
+	return r
+}
+
+// runeAfter 返回 text 中偏移 i 处的字符，i 已到结尾时返回 -1
+func runeAfter(text string, i int) rune {
+	if i >= len(text) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return r
+}
@@ -0,0 +1,142 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// newBoundaryManager 创建全局按 BoundaryAuto 判断边界的测试 Manager
+func newBoundaryManager(t *testing.T, ft uint32) *Manager {
+	t.Helper()
+	return newTestManager(t, FilterOption{Type: ft, WordBoundary: BoundaryAuto}, "ass", "sex", "毒品", "sex视频")
+}
+
+func TestWordBoundary(t *testing.T) {
+	cases := []struct {
+		text string
+		want bool
+	}{
+		{"first class", false},
+		{"Essex", false},
+		{"ｃｌａｓｓ", false}, // 按原文判断：全角字母同样是字母
+		{"kick ass!", true},
+		{"ASS", true},
+		{"ass毒", true}, // 拉丁字母与 CJK 相邻视为边界
+		{"毒品交易", true},
+		{"贩卖毒品的人", true},
+		{"看sex视频吧", true},
+		{"Essex视频", false},
+	}
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newBoundaryManager(t, ft)
+		for _, c := range cases {
+			if got := m.IsSensitive(c.text); got != c.want {
+				t.Fatalf("IsSensitive(%q) = %v", c.text, got)
+			}
+			if got := m.IsSensitiveBytes([]byte(c.text)); got != c.want {
+				t.Fatalf("IsSensitiveBytes(%q) = %v", c.text, got)
+			}
+			if got, _ := m.IsSensitiveContext(context.Background(), c.text); got != c.want {
+				t.Fatalf("IsSensitiveContext(%q) = %v", c.text, got)
+			}
+		}
+	})
+}
+
+func TestWordBoundary_FindAndReplace(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newBoundaryManager(t, ft)
+		text := "the class said ass, Essex sex"
+		if got := m.FindAll(text); !reflect.DeepEqual(got, []string{"ass", "sex"}) {
+			t.Fatalf("FindAll: %v", got)
+		}
+		if got := m.Replace(text, '*'); got != "the class said ***, Essex ***" {
+			t.Fatalf("Replace: %q", got)
+		}
+		if got := m.FindOne("class ass"); got != "ass" {
+			t.Fatalf("FindOne: %q", got)
+		}
+	})
+}
+
+func TestWordBoundary_PerWordRules(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newBoundaryManager(t, ft)
+		// 按词规则优先于全局规则
+		if err := m.SetWordBoundary(BoundaryNone, "sex"); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.SetWordBoundary(BoundaryWord, "毒品"); err != nil {
+			t.Fatal(err)
+		}
+		if m.WordBoundary("SEX") != BoundaryNone || m.WordBoundary("ass") != BoundaryAuto {
+			t.Fatalf("WordBoundary: %v %v", m.WordBoundary("SEX"), m.WordBoundary("ass"))
+		}
+		if !m.IsSensitive("Essex") {
+			t.Fatal("per-word none rule ignored")
+		}
+		if m.IsSensitive("毒品交易") || !m.IsSensitive("毒品!") {
+			t.Fatal("per-word word rule ignored")
+		}
+		if got := m.Tenant("t1").FindAll("first class, Essex"); !reflect.DeepEqual(got, []string{"sex"}) {
+			t.Fatalf("tenant FindAll: %v", got)
+		}
+	})
+}
+
+func TestWordBoundary_Scanner(t *testing.T) {
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "ass", "毒品")
+		if err := m.SetWordBoundary(BoundaryAuto, "ass"); err != nil {
+			t.Fatal(err)
+		}
+
+		text := strings.Repeat("class ass 毒品 grass. ", 50)
+		var got []string
+		s := m.NewScanner(iotest.OneByteReader(strings.NewReader(text)))
+		for s.Scan() {
+			got = append(got, s.Match().Text)
+		}
+		if s.Err() != nil {
+			t.Fatal(s.Err())
+		}
+		var want []string
+		for _, h := range m.nf.scan(text) {
+			want = append(want, text[h.start:h.end])
+		}
+		if len(got) != 100 || !reflect.DeepEqual(got, want) {
+			t.Fatalf("scanner hits: %d %v", len(got), got[:4])
+		}
+		out, err := io.ReadAll(m.NewReplaceReader(iotest.OneByteReader(strings.NewReader(text)), '*'))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != m.Replace(text, '*') || !strings.HasPrefix(string(out), "class *** ** grass.") {
+			t.Fatalf("replace reader: %q", out[:40])
+		}
+	})
+}
+
+func TestWordBoundary_RulesTracked(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "ass", "sex")
+	// 没有按词规则时不检查边界，IsSensitive 走快速路径
+	if m.nf.boundaryActive() {
+		t.Fatal("boundary checks active without any rule")
+	}
+	if err := m.SetWordBoundary(BoundaryAuto, "ass"); err != nil {
+		t.Fatal(err)
+	}
+	if !m.nf.boundaryActive() || m.WordBoundary("ass") != BoundaryAuto || m.WordBoundary("sex") != BoundaryNone {
+		t.Fatal("per-word rule not tracked")
+	}
+	if err := m.DelWord("ass"); err != nil {
+		t.Fatal(err)
+	}
+	if m.nf.boundaryActive() {
+		t.Fatal("boundary checks still active after rule removed")
+	}
+}
@@ -0,0 +1,322 @@
+package go_sensitive_word
+
+import (
+	"unicode/utf8"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/normalize"
+)
+
+// maxPooledScan 超过该长度（规范化字符数）的缓冲不放回池中，避免长期占用内存
+const maxPooledScan = 1 << 16
+
+// streamScan 单遍匹配的临时缓冲（池化复用）
+type streamScan struct {
+	matcher filter.Matcher
+	stream  normalize.Stream
+	offsets []int          // 规范化字符 -> 原文字节起始偏移
+	ends    []int          // 规范化字符 -> 原文字节结束偏移
+	norm    []rune         // 规范化字符（白名单过滤与去重时使用）
+	hits    []filter.Range // 命中区间（规范化索引）
+	marks   []bool         // 原文字节是否需要替换
+	first   bool           // 首次命中即停止
+	max     int            // 命中数上限（0 表示不限制）
+	stop    int            // 命中数达到该值时停止匹配（0 表示不提前停止）
+	owner   *normalizedFilter
+	cfg     NormalizerConfig // 当前归一化配置
+	checks  bool             // 需要按原文检查词边界或干扰字符间隔
+	text    string           // 原文（字符串输入，检查词边界时使用）
+	src     []byte           // 原文（字节切片输入）
+}
+
+// acquire 取出缓冲并绑定当前匹配结构；底层过滤器不支持 filter.Streamer 时返回 nil
+func (nf *normalizedFilter) acquire(first bool) *streamScan {
+	if nf.scans.New == nil {
+		return nil
+	}
+	sc := nf.scans.Get().(*streamScan)
+	sc.matcher.Reset()
+	sc.cfg = nf.config()
+	sc.stream = normalize.NewStream(sc.cfg.toInternalConfig())
+	sc.offsets, sc.ends, sc.norm, sc.hits = sc.offsets[:0], sc.ends[:0], sc.norm[:0], sc.hits[:0]
+	empty := nf.allow.Load().empty()
+	sc.first = first && empty
+	sc.max, sc.stop = nf.limits().MaxMatches, 0
+	sc.owner = nf
+	sc.checks = nf.boundaryActive() || sc.cfg.MaxSkipGap > 0
+	if sc.max > 0 && empty && nf.mode == MatchOverlapping {
+		// 保留全部命中时选取不改变命中顺序，可提前停止；多取一个命中用于判断是否超出上限
+		sc.stop = sc.max + 1
+	}
+	return sc
+}
+
+// feed 归一化原文 [start, end) 处的字符 r 并推进匹配器，返回 true 表示可以停止
+func (sc *streamScan) feed(r rune, start, end int) bool {
+	nr, ok := sc.stream.Next(r)
+	if !ok {
+		return false
+	}
+	sc.offsets = append(sc.offsets, start)
+	sc.ends = append(sc.ends, end)
+	sc.norm = append(sc.norm, nr)
+	sc.matcher.Step(nr)
+	last := len(sc.norm) - 1
+	for k := 0; ; k++ {
+		length := sc.matcher.Output(k)
+		if length == 0 || length > last+1 {
+			return false
+		}
+		r := filter.Range{Start: last - length + 1, End: last}
+		if sc.checks && !sc.accept(r) {
+			continue
+		}
+		sc.hits = append(sc.hits, r)
+		if sc.first || len(sc.hits) == sc.stop {
+			return true
+		}
+	}
+}
+
+// accept 按原文判断命中是否满足词边界规则与干扰字符间隔限制
+func (sc *streamScan) accept(r filter.Range) bool {
+	start, end := sc.offsets[r.Start], sc.ends[r.End]
+	before, after := rune(-1), rune(-1)
+	if sc.src != nil {
+		if sc.cfg.MaxSkipGap > 0 && !sc.cfg.skipGapOK(string(sc.src[start:end])) {
+			return false
+		}
+		if start > 0 {
+			before, _ = utf8.DecodeLastRune(sc.src[:start])
+		}
+		if end < len(sc.src) {
+			after, _ = utf8.DecodeRune(sc.src[end:])
+		}
+	} else {
+		if !sc.cfg.skipGapOK(sc.text[start:end]) {
+			return false
+		}
+		before, after = runeBefore(sc.text, start), runeAfter(sc.text, end)
+	}
+	return sc.owner.bounded(string(sc.norm[r.Start:r.End+1]), before, after)
+}
+
+// finish 剔除白名单覆盖的命中，记录预过滤结果
+func (nf *normalizedFilter) finish(sc *streamScan) *streamScan {
+	if allow := nf.allow.Load(); len(sc.hits) > 0 && !allow.empty() {
+		sc.hits = append(sc.hits[:0], allow.filter(string(sc.norm), sc.hits)...)
+	}
+	nf.prefilterResult(len(sc.hits) > 0)
+	return sc
+}
+
+// direct 判断命中可以不经选取与封顶直接使用（保留全部命中且未超出 MaxMatches）
+func (sc *streamScan) direct() bool {
+	return sc.owner.mode == MatchOverlapping && (sc.max <= 0 || len(sc.hits) <= sc.max)
+}
+
+// scanString 单遍匹配字符串，命中区间写入 sc.hits（已剔除白名单覆盖的命中，未按匹配模式选取与封顶）
+// first 为 true 且没有白名单时，首次命中即返回；预过滤判定无命中时不运行自动机；不支持单遍匹配时返回 nil
+// 输入按资源限制截断
+func (nf *normalizedFilter) scanString(text string, first bool) *streamScan {
+	sc := nf.acquire(first)
+	if sc == nil {
+		return nil
+	}
+	text, _ = nf.limitInput(text)
+	sc.text, sc.src = text, nil
+	if !nf.prefilterString(text) {
+		return sc
+	}
+	for i, r := range text {
+		size := utf8.RuneLen(r)
+		if r == utf8.RuneError {
+			_, size = utf8.DecodeRuneInString(text[i:])
+		}
+		if sc.feed(r, i, i+size) {
+			break
+		}
+	}
+	return nf.finish(sc)
+}
+
+// scanBytes 单遍匹配字节切片，直接遍历 UTF-8，语义同 scanString
+func (nf *normalizedFilter) scanBytes(src []byte, first bool) *streamScan {
+	sc := nf.acquire(first)
+	if sc == nil {
+		return nil
+	}
+	src = nf.limitInputBytes(src)
+	sc.text, sc.src = "", src
+	if !nf.prefilterBytes(src) {
+		return sc
+	}
+	for pos := 0; pos < len(src); {
+		r, size := utf8.DecodeRune(src[pos:])
+		if sc.feed(r, pos, pos+size) {
+			break
+		}
+		pos += size
+	}
+	return nf.finish(sc)
+}
+
+// release 归还缓冲
+func (nf *normalizedFilter) release(sc *streamScan) {
+	sc.text, sc.src = "", nil
+	if cap(sc.norm) > maxPooledScan || cap(sc.marks) > maxPooledScan*utf8.UTFMax {
+		return
+	}
+	nf.scans.Put(sc)
+}
+
+// result 将命中区间转换为原文命中
+func (sc *streamScan) result() []hit {
+	if len(sc.hits) == 0 {
+		return nil
+	}
+	hits := make([]hit, 0, len(sc.hits))
+	for _, r := range sc.hits {
+		hits = append(hits, hit{word: string(sc.norm[r.Start : r.End+1]), span: sc.span(r)})
+	}
+	return hits
+}
+
+// span 返回规范化区间对应的原文字节区间
+func (sc *streamScan) span(r filter.Range) span {
+	return span{start: sc.offsets[r.Start], end: sc.ends[r.End]}
+}
+
+// IsSensitiveBytes 判断字节切片（UTF-8）是否包含敏感词，语义与 IsSensitive 一致
+// 底层过滤器支持逐字符匹配（AC、双数组 AC）时，未命中路径不产生堆分配
+func (nf *normalizedFilter) IsSensitiveBytes(src []byte) bool {
+	sc := nf.scanBytes(src, true)
+	if sc == nil {
+		return nf.IsSensitive(string(src))
+	}
+	defer nf.release(sc)
+	return len(sc.hits) > 0
+}
+
+// FindAllBytes 返回全部敏感词在原文中的片段（按规范化词去重，保留首次出现），语义与 FindAll 一致
+// 返回的切片引用 src 的底层数组；没有命中时返回 nil
+func (nf *normalizedFilter) FindAllBytes(src []byte) [][]byte {
+	sc := nf.scanBytes(src, false)
+	if sc == nil {
+		return findAllBytesHits(src, nf.matchHits(string(src)))
+	}
+	defer nf.release(sc)
+	if !sc.direct() {
+		hits, _ := nf.pickHits(sc.result())
+		return findAllBytesHits(src, hits)
+	}
+	if len(sc.hits) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(sc.hits))
+	res := make([][]byte, 0, len(sc.hits))
+	for _, h := range sc.hits {
+		word := string(sc.norm[h.Start : h.End+1])
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		r := sc.span(h)
+		res = append(res, src[r.start:r.end:r.end])
+	}
+	return res
+}
+
+// findAllBytesHits 按规范化词去重，返回首次出现的原文片段（引用 src 的底层数组）
+func findAllBytesHits(src []byte, hits []hit) [][]byte {
+	if len(hits) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(hits))
+	res := make([][]byte, 0, len(hits))
+	for _, h := range hits {
+		if _, ok := seen[h.word]; !ok {
+			seen[h.word] = struct{}{}
+			res = append(res, src[h.start:h.end:h.end])
+		}
+	}
+	return res
+}
+
+// AppendReplace 将 src 中的敏感词替换为 repl 后追加到 dst 并返回，语义与 Replace 一致
+// 未命中部分按原始字节追加（无效 UTF-8 字节原样保留）；dst 容量足够且未命中时不产生堆分配
+func (nf *normalizedFilter) AppendReplace(dst, src []byte, repl rune) []byte {
+	sc := nf.scanBytes(src, false)
+	if sc == nil {
+		return appendReplaceHits(dst, src, nf.matchHits(string(src)), repl)
+	}
+	defer nf.release(sc)
+	if !sc.direct() {
+		hits, _ := nf.pickHits(sc.result())
+		return appendReplaceHits(dst, src, hits, repl)
+	}
+	if len(sc.hits) == 0 {
+		return append(dst, src...)
+	}
+	if cap(sc.marks) < len(src) {
+		sc.marks = make([]bool, len(src))
+	}
+	marks := sc.marks[:len(src)]
+	for i := range marks {
+		marks[i] = false
+	}
+	for _, h := range sc.hits {
+		markSpan(marks, sc.span(h))
+	}
+	return appendMarkedBytes(dst, src, marks, repl)
+}
+
+// appendReplaceHits 将 src 中命中区间内的字符替换为 repl 后追加到 dst
+func appendReplaceHits(dst, src []byte, hits []hit, repl rune) []byte {
+	if len(hits) == 0 {
+		return append(dst, src...)
+	}
+	marks := make([]bool, len(src))
+	for _, h := range hits {
+		markSpan(marks, h.span)
+	}
+	return appendMarkedBytes(dst, src, marks, repl)
+}
+
+func markSpan(marks []bool, r span) {
+	for i := r.start; i < r.end; i++ {
+		marks[i] = true
+	}
+}
+
+// appendMarkedBytes 将 src 追加到 dst，起始字节被标记的字符替换为 repl
+func appendMarkedBytes(dst, src []byte, marks []bool, repl rune) []byte {
+	for pos := 0; pos < len(src); {
+		_, size := utf8.DecodeRune(src[pos:])
+		if marks[pos] {
+			dst = utf8.AppendRune(dst, repl)
+		} else {
+			dst = append(dst, src[pos:pos+size]...)
+		}
+		pos += size
+	}
+	return dst
+}
+
+// IsSensitiveBytes 判断字节切片（UTF-8）是否包含敏感词，语义与 IsSensitive 一致
+// 使用 AC 或双数组 AC 算法时直接遍历 UTF-8 并复用池化缓冲，未命中路径不产生堆分配
+func (m *Manager) IsSensitiveBytes(src []byte) bool {
+	return m.nf.IsSensitiveBytes(src)
+}
+
+// FindAllBytes 返回全部敏感词在原文中的片段，语义与 FindAll 一致
+// 返回的切片引用 src 的底层数组；没有命中时返回 nil
+func (m *Manager) FindAllBytes(src []byte) [][]byte {
+	return m.nf.FindAllBytes(src)
+}
+
+// AppendReplace 将 src 中的敏感词替换为 repl 后追加到 dst 并返回，语义与 Replace 一致
+// 未命中部分按原始字节追加（无效 UTF-8 字节原样保留）；适合复用输出缓冲：dst 容量足够且未命中时不产生堆分配
+func (m *Manager) AppendReplace(dst, src []byte, repl rune) []byte {
+	return m.nf.AppendReplace(dst, src, repl)
+}
@@ -0,0 +1,70 @@
+package go_sensitive_word
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newBytesManager 创建字节切片接口的测试 Manager
+func newBytesManager(t *testing.T, ft uint32) *Manager {
+	t.Helper()
+	m := newTestManager(t, FilterOption{Type: ft}, "赌博", "赌博机", "SEX", "he", "hers")
+	if err := m.AddAllowWords("Essex"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestBytesAPI(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newBytesManager(t, ft)
+		for _, text := range []string{"他在玩赌博机和赌博", "ＳＥＸ Essex sex", "ushers", "无敏感词", "", "bad\xffutf8赌博"} {
+			if got, want := m.IsSensitiveBytes([]byte(text)), m.IsSensitive(text); got != want {
+				t.Fatalf("is sensitive %q: %v, want %v", text, got, want)
+			}
+			var got []string
+			for _, b := range m.FindAllBytes([]byte(text)) {
+				got = append(got, string(b))
+			}
+			if want := m.FindAll(text); len(want) > 0 || len(got) > 0 {
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("find all %q: %v, want %v", text, got, want)
+				}
+			}
+			if got, want := string(m.AppendReplace([]byte("> "), []byte(text), '*')), "> "+m.Replace(text, '*'); got != want {
+				t.Fatalf("replace %q: %q, want %q", text, got, want)
+			}
+		}
+	})
+}
+
+func TestAppendReplace_InvalidUTF8(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newBytesManager(t, ft)
+		if got := string(m.AppendReplace(nil, []byte("a\xff赌博"), '*')); got != "a\xff**" {
+			t.Fatalf("invalid utf8: %q", got)
+		}
+	})
+}
+
+func TestBytesAPI_NoAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful under the race detector")
+	}
+	// AC 与双数组 AC 的未命中路径不产生堆分配
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newBytesManager(t, ft)
+		clean := []byte("这是一段完全正常的文本，没有任何问题 normal text")
+		dst := make([]byte, 0, 256)
+		m.IsSensitiveBytes(clean)
+		if n := testing.AllocsPerRun(100, func() { m.IsSensitiveBytes(clean) }); n != 0 {
+			t.Fatalf("IsSensitiveBytes allocs: %v", n)
+		}
+		if n := testing.AllocsPerRun(100, func() { m.FindAllBytes(clean) }); n != 0 {
+			t.Fatalf("FindAllBytes allocs: %v", n)
+		}
+		if n := testing.AllocsPerRun(100, func() { dst = m.AppendReplace(dst[:0], clean, '*') }); n != 0 {
+			t.Fatalf("AppendReplace allocs: %v", n)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+// sensitive-compile 将文本词库编译为二进制格式，供 Manager.LoadCompiled 在启动时快速加载，
+// 或编译为扁平自动机文件（-format mapped），供 OpenMapped 以内存映射方式只读使用
+//
+// 用法：
+//
+//	sensitive-compile -o dict.bin [-filter ac|dat|dfa] [-format compiled|mapped] 词库1.txt 词库2.txt ...
+//
+// 每个词库文件的来源标识为去掉扩展名的文件名。配合 go generate 使用：
+//
+//	//go:generate go run github.com/LuYongwang/go-sensitive-word/cmd/sensitive-compile -o dict.bin wordlists/政治类型.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sensitive "github.com/LuYongwang/go-sensitive-word"
+)
+
+func main() {
+	output := flag.String("o", "", "输出文件路径（必填）")
+	filterType := flag.String("filter", "ac", "匹配算法：ac、dat 或 dfa（需与加载时的过滤器一致才能免重建）")
+	format := flag.String("format", "compiled", "输出格式：compiled（LoadCompiled）或 mapped（OpenMapped）")
+	flag.Parse()
+	if *output == "" || flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "用法: sensitive-compile -o dict.bin [-filter ac|dat|dfa] [-format compiled|mapped] 词库文件...")
+		os.Exit(2)
+	}
+	var write func(m *sensitive.Manager, w io.Writer) error
+	switch *format {
+	case "compiled":
+		write = (*sensitive.Manager).WriteCompiled
+	case "mapped":
+		write = (*sensitive.Manager).WriteMapped
+	default:
+		log.Fatalf("未知的输出格式: %s", *format)
+	}
+
+	var ft uint32
+	switch *filterType {
+	case "ac":
+		ft = sensitive.FilterAC
+	case "dat":
+		ft = sensitive.FilterDAT
+	case "dfa":
+		ft = sensitive.FilterDfa
+	default:
+		log.Fatalf("未知的匹配算法: %s", *filterType)
+	}
+	m, err := sensitive.NewFilter(
+		sensitive.StoreOption{Type: sensitive.StoreMemory},
+		sensitive.FilterOption{Type: ft},
+	)
+	if err != nil {
+		log.Fatalf("初始化失败: %v", err)
+	}
+	defer m.Close()
+
+	for _, path := range flag.Args() {
+		source := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		reports, err := m.LoadDictPathWithReport(sensitive.LoadOptions{Source: source}, path)
+		if err != nil {
+			log.Fatalf("加载 %s 失败: %v", path, err)
+		}
+		for _, report := range reports {
+			for _, rejected := range report.Rejected {
+				log.Printf("%s:%d 已跳过（%s）: %q", path, rejected.Line, rejected.Reason, rejected.Text)
+			}
+		}
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		log.Fatalf("创建 %s 失败: %v", *output, err)
+	}
+	if err := write(m, f); err != nil {
+		_ = f.Close()
+		log.Fatalf("编译失败: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("写入 %s 失败: %v", *output, err)
+	}
+	log.Printf("已编译 %d 个词到 %s", m.GetStats().TotalWords, *output)
+}
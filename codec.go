@@ -0,0 +1,317 @@
+package go_sensitive_word
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/store"
+)
+
+// DictFormat 词库导入导出格式
+type DictFormat string
+
+// 内置的词库格式
+const (
+	FormatJSON      DictFormat = "json"  // JSON 数组，每个元素为一个词条
+	FormatCSV       DictFormat = "csv"   // CSV，表头为 word,sources,metadata,originals,canonical
+	FormatJSONLines DictFormat = "jsonl" // JSON Lines，每行一个词条
+	FormatText      DictFormat = "text"  // 纯文本，每行一个词（不含来源与元数据）
+)
+
+// csvSourceSep CSV 格式中多个来源之间的分隔符
+const csvSourceSep = "|"
+
+// DictEntry 词条（词、来源、元数据）
+type DictEntry = store.Entry
+
+// DictCodec 词库编解码器，可通过 RegisterDictCodec 注册自定义格式
+type DictCodec interface {
+	Encode(w io.Writer, entries []DictEntry) error
+	Decode(r io.Reader) ([]DictEntry, error)
+}
+
+var (
+	codecMu sync.RWMutex
+	codecs  = map[DictFormat]DictCodec{
+		FormatJSON:      jsonCodec{},
+		FormatCSV:       csvCodec{},
+		FormatJSONLines: jsonLinesCodec{},
+		FormatText:      textCodec{},
+	}
+)
+
+// RegisterDictCodec 注册（或覆盖）指定格式的编解码器
+func RegisterDictCodec(format DictFormat, codec DictCodec) {
+	codecMu.Lock()
+	defer codecMu.Unlock()
+	codecs[format] = codec
+}
+
+// lookupDictCodec 获取指定格式的编解码器
+func lookupDictCodec(format DictFormat) (DictCodec, error) {
+	codecMu.RLock()
+	defer codecMu.RUnlock()
+	codec, ok := codecs[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dict format %q", format)
+	}
+	return codec, nil
+}
+
+// Export 按指定格式导出词库（含来源与元数据），输出按词排序，便于 diff
+func (m *Manager) Export(w io.Writer, format DictFormat) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	codec, err := lookupDictCodec(format)
+	if err != nil {
+		return err
+	}
+	return codec.Encode(w, m.Store.GetEntries())
+}
+
+// Import 按指定格式导入词库（含来源与元数据）
+// 注意：词会被归一化后再添加，与 Export 配合可无损往返
+func (m *Manager) Import(r io.Reader, format DictFormat) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	codec, err := lookupDictCodec(format)
+	if err != nil {
+		return err
+	}
+	entries, err := codec.Decode(r)
+	if err != nil {
+		return err
+	}
+	return m.AddEntries(entries)
+}
+
+// AddEntries 批量添加词条（含来源、元数据与原始写法）
+// 注意：词会被归一化后再添加，确保与测试文本的归一化策略一致
+func (m *Manager) AddEntries(entries []DictEntry) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	cfg := m.Normalizer()
+	normalized := make([]DictEntry, 0, len(entries))
+	for _, entry := range entries {
+		word := NormalizeWord(entry.Word, cfg)
+		if word == "" {
+			continue
+		}
+		// 未提供原始写法时，以导入的写法作为原始写法
+		entry.Originals = entryOriginals(entry)
+		entry.Word = word
+		if entry.Canonical != "" {
+			entry.Canonical = NormalizeWord(entry.Canonical, cfg)
+		}
+		normalized = append(normalized, entry)
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+	return m.Store.AddEntries(normalized)
+}
+
+// sortedEntries 返回按词排序的词条副本，保证导出结果稳定
+func sortedEntries(entries []DictEntry) []DictEntry {
+	sorted := append([]DictEntry{}, entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Word < sorted[j].Word })
+	return sorted
+}
+
+// ==================== JSON ====================
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, entries []DictEntry) error {
+	entries = sortedEntries(entries)
+	if entries == nil {
+		entries = []DictEntry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+func (jsonCodec) Decode(r io.Reader) ([]DictEntry, error) {
+	var entries []DictEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("decode json dict: %w", err)
+	}
+	return entries, nil
+}
+
+// ==================== JSON Lines ====================
+
+type jsonLinesCodec struct{}
+
+func (jsonLinesCodec) Encode(w io.Writer, entries []DictEntry) error {
+	writer := bufio.NewWriter(w)
+	enc := json.NewEncoder(writer)
+	enc.SetEscapeHTML(false)
+	for _, entry := range sortedEntries(entries) {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (jsonLinesCodec) Decode(r io.Reader) ([]DictEntry, error) {
+	var entries []DictEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry DictEntry
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("decode jsonl dict line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ==================== Text ====================
+
+// textCodec 与 LoadDict / ExportToWriter 相同的纯文本格式，来源与元数据会丢失
+type textCodec struct{}
+
+func (textCodec) Encode(w io.Writer, entries []DictEntry) error {
+	writer := bufio.NewWriter(w)
+	for _, entry := range sortedEntries(entries) {
+		if _, err := writer.WriteString(entry.Word + "\n"); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func (textCodec) Decode(r io.Reader) ([]DictEntry, error) {
+	var entries []DictEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue
+		}
+		entries = append(entries, DictEntry{Word: word})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ==================== CSV ====================
+
+// csvCodec 列依次为 word、sources（以 | 分隔）、metadata（JSON 对象）、originals（JSON 数组）、canonical（别名组规范词）
+// 解码时兼容缺少末尾 originals、canonical 列的旧格式
+type csvCodec struct{}
+
+var csvHeader = []string{"word", "sources", "metadata", "originals", "canonical"}
+
+// csvMinColumns 最早格式（仅 word、sources、metadata）的列数
+const csvMinColumns = 3
+
+func (csvCodec) Encode(w io.Writer, entries []DictEntry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, entry := range sortedEntries(entries) {
+		for _, source := range entry.Sources {
+			if strings.Contains(source, csvSourceSep) {
+				return fmt.Errorf("source %q of word %q contains csv separator %q", source, entry.Word, csvSourceSep)
+			}
+		}
+		meta := ""
+		if len(entry.Metadata) > 0 {
+			b, err := json.Marshal(entry.Metadata)
+			if err != nil {
+				return err
+			}
+			meta = string(b)
+		}
+		originals := ""
+		if len(entry.Originals) > 0 {
+			b, err := json.Marshal(entry.Originals)
+			if err != nil {
+				return err
+			}
+			originals = string(b)
+		}
+		record := []string{entry.Word, strings.Join(entry.Sources, csvSourceSep), meta, originals, entry.Canonical}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func (csvCodec) Decode(r io.Reader) ([]DictEntry, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("decode csv dict header: %w", err)
+	}
+	if len(header) < csvMinColumns || len(header) > len(csvHeader) {
+		return nil, fmt.Errorf("invalid csv dict header %v, expect %v", header, csvHeader)
+	}
+	for i, name := range csvHeader[:len(header)] {
+		if strings.TrimSpace(header[i]) != name {
+			return nil, fmt.Errorf("invalid csv dict header %v, expect %v", header, csvHeader)
+		}
+	}
+	var entries []DictEntry
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decode csv dict: %w", err)
+		}
+		entry := DictEntry{Word: record[0]}
+		if record[1] != "" {
+			entry.Sources = strings.Split(record[1], csvSourceSep)
+		}
+		if record[2] != "" {
+			if err := json.Unmarshal([]byte(record[2]), &entry.Metadata); err != nil {
+				line, _ := reader.FieldPos(2)
+				return nil, fmt.Errorf("decode csv dict metadata at line %d: %w", line, err)
+			}
+		}
+		if len(record) > 3 && record[3] != "" {
+			if err := json.Unmarshal([]byte(record[3]), &entry.Originals); err != nil {
+				line, _ := reader.FieldPos(3)
+				return nil, fmt.Errorf("decode csv dict originals at line %d: %w", line, err)
+			}
+		}
+		if len(record) > 4 {
+			entry.Canonical = record[4]
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,56 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	src := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := src.AddWordsWithSource([]string{"违禁词", "badword"}, "custom"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddWordsWithSource([]string{"违禁词"}, "political"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddWord("无来源"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddEntries([]DictEntry{{Word: "badword", Metadata: map[string]string{"level": "high", "note": "a,b\"c"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []DictFormat{FormatJSON, FormatCSV, FormatJSONLines} {
+		format := format
+		t.Run(string(format), func(t *testing.T) {
+			var first bytes.Buffer
+			if err := src.Export(&first, format); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+
+			dst := newTestManager(t, FilterOption{Type: FilterAC})
+			if err := dst.Import(bytes.NewReader(first.Bytes()), format); err != nil {
+				t.Fatalf("import: %v", err)
+			}
+			if !reflect.DeepEqual(src.GetEntries(), dst.GetEntries()) {
+				t.Fatalf("round trip mismatch:\n%v\n%v", src.GetEntries(), dst.GetEntries())
+			}
+
+			var second bytes.Buffer
+			if err := dst.Export(&second, format); err != nil {
+				t.Fatal(err)
+			}
+			if first.String() != second.String() {
+				t.Fatalf("export not deterministic:\n%s\n%s", first.String(), second.String())
+			}
+		})
+	}
+}
+
+func TestImport_UnknownFormat(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterDfa})
+	if err := m.Import(bytes.NewReader(nil), DictFormat("xml")); err == nil {
+		t.Fatal("expect error for unknown format")
+	}
+}
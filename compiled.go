@@ -0,0 +1,222 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// NormalizerVersion 归一化算法版本
+// 归一化规则或映射表（繁简、同形字等）发生变化时递增，此前编译的词库需要重新生成
+const NormalizerVersion = 1
+
+// 编译词库格式标识与版本
+const (
+	compiledMagic         = "GSWC"
+	compiledFormatVersion = 1
+)
+
+var (
+	// ErrCompiledChecksum 编译词库校验和不匹配（文件损坏或被截断）
+	ErrCompiledChecksum = errors.New("compiled dictionary checksum mismatch")
+	// ErrNormalizerVersion 编译词库的归一化算法版本与当前版本不一致，需要重新生成
+	ErrNormalizerVersion = errors.New("compiled dictionary normalizer version mismatch")
+)
+
+// compiledDict 编译词库的内容
+type compiledDict struct {
+	kind       string           // 匹配结构类型（如 "ac"、"dfa"）
+	normalizer NormalizerConfig // 归一化配置
+	version    uint64           // 词库版本号
+	entries    []DictEntry      // 词条（含来源、元数据、原始写法、别名组）
+	allowWords []string         // 白名单短语
+	automaton  []byte           // 构建完成的匹配结构
+}
+
+// WriteCompiled 将词库编译为二进制格式写入 w，包含构建完成的匹配结构（含失败指针）、
+// 词条来源与元数据、归一化配置与白名单，供 LoadCompiled 快速加载
+// 格式：魔数 "GSWC"、格式版本、归一化算法版本、内容，末尾为 CRC32 校验和
+// 匹配结构按当前词库完整构建，不依赖后台同步进度
+func (m *Manager) WriteCompiled(w io.Writer) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	marshaler, ok := m.nf.inner.(filter.Marshaler)
+	if !ok {
+		return fmt.Errorf("filter %T does not support compilation", m.nf.inner)
+	}
+	dict := compiledDict{
+		kind:       marshaler.AutomatonKind(),
+		normalizer: m.Normalizer(),
+		version:    m.Store.GetStats().Version,
+		entries:    m.Store.GetEntries(),
+		allowWords: m.GetAllowWords(),
+	}
+	words := make([]string, 0, len(dict.entries))
+	for _, entry := range dict.entries {
+		words = append(words, entry.Word)
+	}
+	automaton, err := marshaler.MarshalAutomaton(words)
+	if err != nil {
+		return err
+	}
+	dict.automaton = automaton
+	data, err := encodeCompiled(&dict)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// LoadCompiled 加载 WriteCompiled 生成的编译词库，整体替换当前词库、归一化配置与白名单
+// 加载是原子的：数据会先完整校验（魔数、格式版本、校验和、归一化算法版本），任何错误都不会修改当前状态
+// 匹配结构类型与当前过滤器一致时直接恢复，无需重新构建；类型不一致或匹配结构无法恢复时按词表重建
+func (m *Manager) LoadCompiled(r io.Reader) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	dict, err := decodeCompiled(data)
+	if err != nil {
+		return err
+	}
+	allow := newAllowList(dict.allowWords)
+
+	m.restoreMu.Lock()
+	defer m.restoreMu.Unlock()
+	m.allowMu.Lock()
+	defer m.allowMu.Unlock()
+
+	var load func() error
+	if marshaler, ok := m.nf.inner.(filter.Marshaler); ok && marshaler.AutomatonKind() == dict.kind {
+		load = func() error { return marshaler.UnmarshalAutomaton(dict.automaton) }
+	}
+	return m.replaceState(dict.entries, dict.normalizer, allow, dict.version, load)
+}
+
+func encodeCompiled(dict *compiledDict) ([]byte, error) {
+	cfg, err := json.Marshal(dict.normalizer)
+	if err != nil {
+		return nil, err
+	}
+	var w filter.BinaryWriter
+	w.Raw([]byte(compiledMagic))
+	w.Uvarint(compiledFormatVersion)
+	w.Uvarint(NormalizerVersion)
+	w.String(dict.kind)
+	w.String(string(cfg))
+	w.Uvarint(dict.version)
+
+	w.Uvarint(uint64(len(dict.entries)))
+	for _, entry := range dict.entries {
+		w.String(entry.Word)
+		writeStrings(&w, entry.Sources)
+		keys := make([]string, 0, len(entry.Metadata))
+		for k := range entry.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.Uvarint(uint64(len(keys)))
+		for _, k := range keys {
+			w.String(k)
+			w.String(entry.Metadata[k])
+		}
+		w.Uvarint(uint64(len(entry.Originals)))
+		for _, o := range entry.Originals {
+			w.String(o.Text)
+			writeStrings(&w, o.Sources)
+		}
+		w.String(entry.Canonical)
+	}
+	writeStrings(&w, dict.allowWords)
+	w.String(string(dict.automaton))
+
+	sum := crc32.ChecksumIEEE(w.Bytes())
+	w.Raw(binary.BigEndian.AppendUint32(nil, sum))
+	return w.Bytes(), nil
+}
+
+func decodeCompiled(data []byte) (*compiledDict, error) {
+	if len(data) < len(compiledMagic)+4 || !bytes.Equal(data[:len(compiledMagic)], []byte(compiledMagic)) {
+		return nil, errors.New("invalid compiled dictionary: bad magic")
+	}
+	body := data[:len(data)-4]
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(data[len(data)-4:]) {
+		return nil, ErrCompiledChecksum
+	}
+	r := filter.NewBinaryReader(body[len(compiledMagic):])
+	if v := r.Uvarint(); r.Err() == nil && v != compiledFormatVersion {
+		return nil, fmt.Errorf("unsupported compiled dictionary format version %d", v)
+	}
+	if v := r.Uvarint(); r.Err() == nil && v != NormalizerVersion {
+		return nil, fmt.Errorf("%w: compiled with normalizer version %d, current version is %d; regenerate the compiled dictionary",
+			ErrNormalizerVersion, v, NormalizerVersion)
+	}
+
+	dict := &compiledDict{kind: r.String()}
+	cfg := r.Bytes()
+	dict.version = r.Uvarint()
+	if r.Err() != nil {
+		return nil, fmt.Errorf("decode compiled dictionary: %w", r.Err())
+	}
+	if err := json.Unmarshal(cfg, &dict.normalizer); err != nil {
+		return nil, fmt.Errorf("decode compiled dictionary normalizer: %w", err)
+	}
+
+	n := r.Count()
+	dict.entries = make([]DictEntry, 0, n)
+	for i := 0; i < n && r.Err() == nil; i++ {
+		entry := DictEntry{Word: r.String(), Sources: readStrings(r)}
+		if metaCount := r.Count(); metaCount > 0 {
+			entry.Metadata = make(map[string]string, metaCount)
+			for k := 0; k < metaCount; k++ {
+				key := r.String()
+				entry.Metadata[key] = r.String()
+			}
+		}
+		if count := r.Count(); count > 0 {
+			entry.Originals = make([]DictOriginal, count)
+			for k := range entry.Originals {
+				entry.Originals[k] = DictOriginal{Text: r.String(), Sources: readStrings(r)}
+			}
+		}
+		entry.Canonical = r.String()
+		dict.entries = append(dict.entries, entry)
+	}
+	dict.allowWords = readStrings(r)
+	dict.automaton = r.Bytes()
+	if r.Err() != nil || !r.Done() {
+		return nil, fmt.Errorf("decode compiled dictionary: %w", filter.ErrCorrupted)
+	}
+	return dict, nil
+}
+
+func writeStrings(w *filter.BinaryWriter, list []string) {
+	w.Uvarint(uint64(len(list)))
+	for _, s := range list {
+		w.String(s)
+	}
+}
+
+func readStrings(r *filter.BinaryReader) []string {
+	n := r.Count()
+	if n == 0 {
+		return nil
+	}
+	list := make([]string, n)
+	for i := range list {
+		list[i] = r.String()
+	}
+	return list
+}
@@ -0,0 +1,115 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"reflect"
+	"testing"
+)
+
+// testCompiled 返回编译词库所用的 Manager 及编译结果
+func testCompiled(t *testing.T) (*Manager, []byte) {
+	t.Helper()
+	src := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := src.AddWordsWithSource([]string{"赌博", "毒品", "SEX"}, "base"); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.AddAllowWords("Essex"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := src.WriteCompiled(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return src, buf.Bytes()
+}
+
+func TestCompiled_RoundTrip(t *testing.T) {
+	src, data := testCompiled(t)
+	forEachFilter(t, []uint32{FilterAC, FilterDfa}, func(t *testing.T, ft uint32) {
+		dst := newTestManager(t, FilterOption{Type: ft})
+		if err := dst.LoadCompiled(bytes.NewReader(data)); err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		// 匹配结构同步恢复，无需等待后台同步
+		if got := dst.FindAll("赌博 sex Essex 毒品"); !reflect.DeepEqual(got, []string{"赌博", "sex", "毒品"}) {
+			t.Fatalf("find all: %v", got)
+		}
+		if !reflect.DeepEqual(dst.GetEntries(), src.GetEntries()) {
+			t.Fatal("entries mismatch")
+		}
+	})
+}
+
+func TestCompiled_Checksum(t *testing.T) {
+	src, data := testCompiled(t)
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)/2] ^= 0xff
+	if err := src.LoadCompiled(bytes.NewReader(corrupted)); !errors.Is(err, ErrCompiledChecksum) {
+		t.Fatalf("expect checksum error, got %v", err)
+	}
+}
+
+func TestCompiled_NormalizerVersion(t *testing.T) {
+	src, data := testCompiled(t)
+	// 第 6 个字节为归一化算法版本
+	stale := append([]byte{}, data[:len(data)-4]...)
+	stale[5] = NormalizerVersion + 1
+	stale = binary.BigEndian.AppendUint32(stale, crc32.ChecksumIEEE(stale))
+	if err := src.LoadCompiled(bytes.NewReader(stale)); !errors.Is(err, ErrNormalizerVersion) {
+		t.Fatalf("expect normalizer version error, got %v", err)
+	}
+}
+
+func TestCompiled_DAT(t *testing.T) {
+	src := newTestManager(t, FilterOption{Type: FilterDAT}, "赌博", "毒品")
+	var buf bytes.Buffer
+	if err := src.WriteCompiled(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// sensitive-compile -filter dat 生成的文件：双数组自动机直接恢复
+	dst := newTestManager(t, FilterOption{Type: FilterDAT})
+	if err := dst.LoadCompiled(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := dst.FindAll("赌博和毒品"); !reflect.DeepEqual(got, []string{"赌博", "毒品"}) {
+		t.Fatalf("find all: %v", got)
+	}
+}
+
+func TestCompiled_DropsQueuedUpdates(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft})
+		// 经公开 API 写入，由后台监听协程同步到过滤器
+		if err := m.AddWord("base"); err != nil {
+			t.Fatal(err)
+		}
+		waitForSensitive(t, m, "base")
+		var buf bytes.Buffer
+		if err := m.WriteCompiled(&buf); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 5; i++ {
+			// 直接恢复匹配结构时，之前已排队、尚未应用的增删同样被丢弃
+			if err := m.AddWord("foo"); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.DelWord("base"); err != nil {
+				t.Fatal(err)
+			}
+			if err := m.LoadCompiled(bytes.NewReader(buf.Bytes())); err != nil {
+				t.Fatal(err)
+			}
+			waitForListener(t, m)
+			if _, ok := m.GetEntry("foo"); ok || m.IsSensitive("foo") {
+				t.Fatalf("round %d: stale add applied after load", i)
+			}
+			if _, ok := m.GetEntry("base"); !ok || !m.IsSensitive("base") {
+				t.Fatalf("round %d: stale delete applied after load", i)
+			}
+		}
+	})
+}
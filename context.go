@@ -0,0 +1,142 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/normalize"
+)
+
+// ctxCheckInterval 上下文感知方法每处理多少个字符检查一次 ctx
+const ctxCheckInterval = 4096
+
+// scanContext 同 scan，归一化与匹配过程中定期检查 ctx，命中已按匹配模式选取并封顶
+// 取消时返回已处理部分的命中与 ctx.Err()；first 为 true 且没有白名单时首次命中即返回
+// 输入被截断或命中数被封顶时（且未取消）返回结果与 *LimitError
+func (nf *normalizedFilter) scanContext(ctx context.Context, text string, first bool) ([]hit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	text, inputErr := nf.limitInput(text)
+	hits, err := nf.scanContextLimited(ctx, text, first)
+	hits, limitErr := nf.pickHits(hits)
+	switch {
+	case err != nil:
+		return hits, err
+	case inputErr != nil:
+		return hits, inputErr
+	}
+	return hits, limitErr
+}
+
+func (nf *normalizedFilter) scanContextLimited(ctx context.Context, text string, first bool) ([]hit, error) {
+	if sc := nf.acquire(first); sc != nil {
+		defer nf.release(sc)
+		sc.text = text
+		if !nf.prefilterString(text) {
+			return nil, nil
+		}
+		var err error
+		n := 0
+		for i, r := range text {
+			if n++; n%ctxCheckInterval == 0 {
+				if err = ctx.Err(); err != nil {
+					break
+				}
+			}
+			size := utf8.RuneLen(r)
+			if r == utf8.RuneError {
+				_, size = utf8.DecodeRuneInString(text[i:])
+			}
+			if sc.feed(r, i, i+size) {
+				break
+			}
+		}
+		nf.finish(sc)
+		return sc.result(), err
+	}
+
+	if !nf.prefilterString(text) {
+		return nil, nil
+	}
+	// 归一化中途取消时只匹配已归一化的部分
+	normText, idxMap, err := normalizeContext(ctx, text, nf.config())
+	rNorm := []rune(normText)
+	var ranges []filter.Range
+	if cf, ok := nf.inner.(filter.ContextRangedFilter); ok && err == nil {
+		ranges, err = cf.FindAllRangesContext(ctx, normText)
+	} else {
+		ranges = nf.findRanges(normText, rNorm)
+	}
+	if len(ranges) > 0 {
+		ranges = nf.allow.Load().filter(normText, ranges)
+	}
+	hits := nf.acceptHits(text, rangeHits(text, rNorm, idxMap, ranges))
+	nf.prefilterResult(len(hits) > 0)
+	return hits, err
+}
+
+// normalizeContext 同 NormalizeTextWithMap，定期检查 ctx，取消时返回已处理部分的规范化文本、索引映射与 ctx.Err()
+func normalizeContext(ctx context.Context, s string, cfg NormalizerConfig) (string, []int, error) {
+	norm := make([]rune, 0, len(s))
+	idxMap := make([]int, 0, len(s))
+	stream := normalize.NewStream(cfg.toInternalConfig())
+	i := 0
+	for _, r := range s {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return string(norm), idxMap, err
+			}
+		}
+		if nr, ok := stream.Next(r); ok {
+			norm = append(norm, nr)
+			idxMap = append(idxMap, i)
+		}
+		i++
+	}
+	return string(norm), idxMap, nil
+}
+
+// IsSensitiveContext 同 IsSensitive，匹配过程中定期检查 ctx，取消时返回 ctx.Err()
+// 取消前已找到命中时仍返回 true
+func (m *Manager) IsSensitiveContext(ctx context.Context, text string) (bool, error) {
+	hits, err := m.nf.scanContext(ctx, text, true)
+	return len(hits) > 0, err
+}
+
+// FindOneContext 同 FindOne，匹配过程中定期检查 ctx，取消时返回已找到的首个敏感词（可能为空）与 ctx.Err()
+func (m *Manager) FindOneContext(ctx context.Context, text string) (string, error) {
+	hits, err := m.nf.scanContext(ctx, text, true)
+	if len(hits) == 0 {
+		return "", err
+	}
+	return text[hits[0].start:hits[0].end], err
+}
+
+// FindAllContext 同 FindAll，匹配过程中定期检查 ctx，取消时返回已处理部分的敏感词与 ctx.Err()
+// 超出 Limits 的输入长度或命中数时返回截断后的结果与 *LimitError
+func (m *Manager) FindAllContext(ctx context.Context, text string) ([]string, error) {
+	hits, err := m.nf.scanContext(ctx, text, false)
+	return findAllHits(text, hits), err
+}
+
+// FindAllCountContext 同 FindAllCount，匹配过程中定期检查 ctx，取消时返回已处理部分的计数与 ctx.Err()
+func (m *Manager) FindAllCountContext(ctx context.Context, text string) (map[string]int, error) {
+	hits, err := m.nf.scanContext(ctx, text, false)
+	return countHits(text, hits), err
+}
+
+// ReplaceContext 同 Replace，匹配过程中定期检查 ctx
+// 取消时仅替换已处理部分的命中，并返回 ctx.Err()
+func (m *Manager) ReplaceContext(ctx context.Context, text string, repl rune) (string, error) {
+	hits, err := m.nf.scanContext(ctx, text, false)
+	return replaceHits(text, hits, repl, false), err
+}
+
+// RemoveContext 同 Remove，匹配过程中定期检查 ctx
+// 取消时仅删除已处理部分的命中，并返回 ctx.Err()
+func (m *Manager) RemoveContext(ctx context.Context, text string) (string, error) {
+	hits, err := m.nf.scanContext(ctx, text, false)
+	return replaceHits(text, hits, 0, true), err
+}
@@ -0,0 +1,101 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContextMatch(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "赌博", "毒品", "SEX")
+		ctx := context.Background()
+		text := "有人在赌博，还有人贩卖毒品和ＳＥＸ，赌博"
+		if got, err := m.FindAllContext(ctx, text); err != nil || !reflect.DeepEqual(got, m.FindAll(text)) {
+			t.Fatalf("find all: %v, %v", got, err)
+		}
+		if got, err := m.FindAllCountContext(ctx, text); err != nil || !reflect.DeepEqual(got, m.FindAllCount(text)) {
+			t.Fatalf("find all count: %v, %v", got, err)
+		}
+		if got, err := m.ReplaceContext(ctx, text, '*'); err != nil || got != m.Replace(text, '*') {
+			t.Fatalf("replace: %q, %v", got, err)
+		}
+		if got, err := m.RemoveContext(ctx, text); err != nil || got != m.Remove(text) {
+			t.Fatalf("remove: %q, %v", got, err)
+		}
+		if got, err := m.FindOneContext(ctx, text); err != nil || got != m.FindOne(text) {
+			t.Fatalf("find one: %q, %v", got, err)
+		}
+		if got, err := m.IsSensitiveContext(ctx, "正常文本"); err != nil || got {
+			t.Fatalf("is sensitive: %v, %v", got, err)
+		}
+	})
+}
+
+func TestContextMatch_Canceled(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "赌博")
+		// 已取消的 ctx 直接返回
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := m.FindAllContext(ctx, "赌博"); !errors.Is(err, context.Canceled) {
+			t.Fatalf("canceled: %v", err)
+		}
+	})
+}
+
+func TestContextMatch_Deadline(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "赌博", "毒品")
+		// 超时中断超长文本：到期后不再继续处理，返回部分结果
+		long := "赌博" + strings.Repeat("正常文本", 2_000_000) + "毒品"
+		ctx := &cancelAfter{Context: context.Background(), n: 2, err: context.DeadlineExceeded}
+		got, err := m.ReplaceContext(ctx, long, '*')
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("deadline: %v", err)
+		}
+		if ctx.checks > 3 {
+			t.Fatalf("kept processing after the deadline: %d checks", ctx.checks)
+		}
+		if !strings.HasPrefix(got, "**") || !strings.HasSuffix(got, "毒品") {
+			t.Fatal("partial replace changed unprocessed text")
+		}
+	})
+}
+
+// cancelAfter 前 n 次 Err 返回 nil，之后返回 err（默认 context.Canceled），用于在处理中途确定地取消；
+// checks 记录 Err 被调用的次数
+type cancelAfter struct {
+	context.Context
+	n      int
+	err    error
+	checks int
+}
+
+func (c *cancelAfter) Err() error {
+	c.checks++
+	if c.n--; c.n < 0 {
+		if c.err != nil {
+			return c.err
+		}
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestContextMatch_PartialOnCancel(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "赌博", "毒品")
+		// 第二次检查时取消：只处理了开头的一段
+		text := "赌博" + strings.Repeat("正常", ctxCheckInterval) + "毒品"
+		got, err := m.FindAllContext(&cancelAfter{Context: context.Background(), n: 2}, text)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err: %v", err)
+		}
+		if !reflect.DeepEqual(got, []string{"赌博"}) {
+			t.Fatalf("partial result: %v", got)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var testDATWords = []string{"he", "she", "his", "hers", "赌博", "赌博机", "SEX", "𠀀字"}
+
+// checkSameAsAC 检查双数组过滤器 m 与 AC 过滤器 ref 的匹配结果一致
+func checkSameAsAC(t *testing.T, m, ref *Manager) {
+	t.Helper()
+	for _, text := range []string{"ushers", "他在玩赌博机和赌博", "SEX sex Sex", "𠀀字𠀀", "无敏感词", ""} {
+		if got, want := m.FindAll(text), ref.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Fatalf("find all %q: %v, want %v", text, got, want)
+		}
+		if got, want := m.FindAllCount(text), ref.FindAllCount(text); !reflect.DeepEqual(got, want) {
+			t.Fatalf("find all count %q: %v, want %v", text, got, want)
+		}
+		if got, want := m.FindOne(text), ref.FindOne(text); got != want {
+			t.Fatalf("find one %q: %q, want %q", text, got, want)
+		}
+		if got, want := m.Replace(text, '*'), ref.Replace(text, '*'); got != want {
+			t.Fatalf("replace %q: %q, want %q", text, got, want)
+		}
+		if got, want := m.Remove(text), ref.Remove(text); got != want {
+			t.Fatalf("remove %q: %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestFilterDAT(t *testing.T) {
+	ref := newTestManager(t, FilterOption{Type: FilterAC}, testDATWords...)
+	m := newTestManager(t, FilterOption{Type: FilterDAT}, testDATWords...)
+	checkSameAsAC(t, m, ref)
+}
+
+func TestFilterDAT_Delete(t *testing.T) {
+	ref := newTestManager(t, FilterOption{Type: FilterAC}, testDATWords...)
+	m := newTestManager(t, FilterOption{Type: FilterDAT}, testDATWords...)
+	// 动态删除后整体重建：等待监听协程处理删除
+	for name, f := range map[string]*Manager{"ac": ref, "dat": m} {
+		f := f
+		if err := f.DelWord("赌博机"); err != nil {
+			t.Fatal(err)
+		}
+		waitUntil(t, func() bool { return len(f.FindAll("赌博机")) == 1 }, "%s filter not updated in time", name)
+	}
+	checkSameAsAC(t, m, ref)
+}
+
+func TestFilterDAT_CompiledRestore(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterDAT}, testDATWords...)
+	// 编译词库直接恢复双数组
+	var buf bytes.Buffer
+	if err := m.WriteCompiled(&buf); err != nil {
+		t.Fatal(err)
+	}
+	restored := newTestManager(t, FilterOption{Type: FilterDAT})
+	if err := restored.LoadCompiled(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.FindAll("ushers 赌博机"); !reflect.DeepEqual(got, m.FindAll("ushers 赌博机")) {
+		t.Fatalf("restored find all: %v", got)
+	}
+	// 恢复后仍可增量修改：等待监听协程写入
+	if err := restored.AddWord("新词"); err != nil {
+		t.Fatal(err)
+	}
+	waitForSensitive(t, restored, "新词")
+	if !restored.IsSensitive("hers") {
+		t.Fatal("words lost after restore")
+	}
+}
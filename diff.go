@@ -0,0 +1,256 @@
+package go_sensitive_word
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/store"
+)
+
+// DictSource 参与 diff 的词库，可由 Manager、store.Store、词库文件或备份文件构造
+type DictSource interface {
+	// load 返回词库的词条（尚未按 diff 配置归一化，原始写法记录在 Originals 中）与词库自身的归一化配置（无则为 nil）
+	load() ([]DictEntry, *NormalizerConfig, error)
+}
+
+type managerSource struct{ m *Manager }
+
+func (s managerSource) load() ([]DictEntry, *NormalizerConfig, error) {
+	if s.m == nil || s.m.Store == nil {
+		return nil, nil, errors.New("store is nil")
+	}
+	cfg := s.m.Normalizer()
+	return s.m.Store.GetEntries(), &cfg, nil
+}
+
+type storeSource struct{ s store.Store }
+
+func (s storeSource) load() ([]DictEntry, *NormalizerConfig, error) {
+	if s.s == nil {
+		return nil, nil, errors.New("store is nil")
+	}
+	return s.s.GetEntries(), nil, nil
+}
+
+type fileSource struct {
+	path   string
+	format DictFormat
+}
+
+func (s fileSource) load() ([]DictEntry, *NormalizerConfig, error) {
+	codec, err := lookupDictCodec(s.format)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = f.Close() }()
+	entries, err := codec.Decode(f)
+	return entries, nil, err
+}
+
+type backupSource struct{ path string }
+
+// load 只读取并解析一次备份文件，词条与归一化配置来自同一份备份
+func (s backupSource) load() ([]DictEntry, *NormalizerConfig, error) {
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = f.Close() }()
+	archive, err := readBackup(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return archive.Entries, &archive.Normalizer, nil
+}
+
+// DictFromManager 使用 Manager 当前的词库参与 diff
+func DictFromManager(m *Manager) DictSource { return managerSource{m: m} }
+
+// DictFromStore 使用词库存储参与 diff
+func DictFromStore(s store.Store) DictSource { return storeSource{s: s} }
+
+// DictFromFile 使用词库文件参与 diff，format 为文件格式（如 FormatText、FormatJSON）
+func DictFromFile(path string, format DictFormat) DictSource {
+	return fileSource{path: path, format: format}
+}
+
+// DictFromBackup 使用 Backup 生成的备份文件（某个历史版本）参与 diff
+func DictFromBackup(path string) DictSource { return backupSource{path: path} }
+
+// DiffEntry diff 中的一个词
+type DiffEntry struct {
+	Word       string   `json:"word"`                  // 归一化后的词
+	OldSources []string `json:"old_sources,omitempty"` // 旧词库中的来源
+	NewSources []string `json:"new_sources,omitempty"` // 新词库中的来源
+}
+
+// DiffCollapse 多个原始写法归一化后为同一个词
+type DiffCollapse struct {
+	Word      string   `json:"word"`      // 归一化后的词
+	Spellings []string `json:"spellings"` // 两侧出现过的全部原始写法
+}
+
+// DictDiff 两个词库之间的差异（基于归一化后的词计算）
+type DictDiff struct {
+	Added         []DiffEntry    `json:"added"`          // 新词库新增的词
+	Removed       []DiffEntry    `json:"removed"`        // 新词库删除的词
+	SourceChanged []DiffEntry    `json:"source_changed"` // 来源发生变化的词
+	Collapsed     []DiffCollapse `json:"collapsed"`      // 原始写法不同但归一化后相同的词
+}
+
+// Empty 判断是否没有任何差异（Collapsed 仅为提示信息，不计入差异）
+func (d *DictDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.SourceChanged) == 0
+}
+
+// Diff 计算从旧词库 a 到新词库 b 的差异
+// 词按 a 的归一化配置（a 无配置时使用 b 的配置，均无时使用 DefaultNormalizer）归一化后比较
+func Diff(a, b DictSource) (*DictDiff, error) {
+	oldEntries, oldCfg, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	newEntries, newCfg, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	cfg := DefaultNormalizer()
+	if oldCfg != nil {
+		cfg = *oldCfg
+	} else if newCfg != nil {
+		cfg = *newCfg
+	}
+	return diffEntries(oldEntries, newEntries, cfg), nil
+}
+
+// DiffWithNormalizer 使用指定的归一化配置计算从旧词库 a 到新词库 b 的差异
+func DiffWithNormalizer(a, b DictSource, cfg NormalizerConfig) (*DictDiff, error) {
+	oldEntries, _, err := a.load()
+	if err != nil {
+		return nil, err
+	}
+	newEntries, _, err := b.load()
+	if err != nil {
+		return nil, err
+	}
+	return diffEntries(oldEntries, newEntries, cfg), nil
+}
+
+// diffEntries 按 cfg 归一化两侧的词条后计算差异
+func diffEntries(oldEntries, newEntries []DictEntry, cfg NormalizerConfig) *DictDiff {
+	oldSet := groupForDiff(oldEntries, cfg)
+	newSet := groupForDiff(newEntries, cfg)
+
+	diff := &DictDiff{}
+	for word, o := range oldSet {
+		n, ok := newSet[word]
+		if !ok {
+			diff.Removed = append(diff.Removed, DiffEntry{Word: word, OldSources: o.sources})
+			continue
+		}
+		if strings.Join(o.sources, "\x00") != strings.Join(n.sources, "\x00") {
+			diff.SourceChanged = append(diff.SourceChanged, DiffEntry{Word: word, OldSources: o.sources, NewSources: n.sources})
+		}
+	}
+	for word, n := range newSet {
+		if _, ok := oldSet[word]; !ok {
+			diff.Added = append(diff.Added, DiffEntry{Word: word, NewSources: n.sources})
+		}
+	}
+
+	// 汇总两侧的原始写法
+	spellings := make(map[string][]string)
+	for _, set := range []map[string]*diffGroup{oldSet, newSet} {
+		for word, g := range set {
+			spellings[word] = unionStrings(spellings[word], g.spellings)
+		}
+	}
+	for word, list := range spellings {
+		if len(list) > 1 {
+			sort.Strings(list)
+			diff.Collapsed = append(diff.Collapsed, DiffCollapse{Word: word, Spellings: list})
+		}
+	}
+
+	sortDiffEntries(diff.Added)
+	sortDiffEntries(diff.Removed)
+	sortDiffEntries(diff.SourceChanged)
+	sort.Slice(diff.Collapsed, func(i, j int) bool { return diff.Collapsed[i].Word < diff.Collapsed[j].Word })
+	return diff
+}
+
+// diffGroup 归一化后同一个词的聚合信息
+type diffGroup struct {
+	sources   []string // 来源（排序去重）
+	spellings []string // 原始写法
+}
+
+func groupForDiff(entries []DictEntry, cfg NormalizerConfig) map[string]*diffGroup {
+	groups := make(map[string]*diffGroup, len(entries))
+	for _, entry := range entries {
+		// 词条中的词已按其所在词库的配置归一化，从原始写法按 cfg 重新归一化
+		words, originals := renormalizeOriginals(entryOriginals(entry), cfg)
+		for _, word := range words {
+			g, ok := groups[word]
+			if !ok {
+				g = &diffGroup{}
+				groups[word] = g
+			}
+			g.sources = unionStrings(g.sources, splitSources(entry.Sources, words, originals[word]))
+			for _, original := range originals[word] {
+				g.spellings = unionStrings(g.spellings, []string{original.Text})
+			}
+		}
+	}
+	for _, g := range groups {
+		sort.Strings(g.sources)
+	}
+	return groups
+}
+
+func sortDiffEntries(entries []DiffEntry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Word < entries[j].Word })
+}
+
+// WriteJSON 以 JSON 格式输出差异
+func (d *DictDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// WriteText 以文本格式输出差异，每行一项（+ 新增、- 删除、~ 来源变化、= 原始写法归一化后相同）
+func (d *DictDiff) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, e := range d.Added {
+		fmt.Fprintf(&b, "+ %s %v\n", e.Word, e.NewSources)
+	}
+	for _, e := range d.Removed {
+		fmt.Fprintf(&b, "- %s %v\n", e.Word, e.OldSources)
+	}
+	for _, e := range d.SourceChanged {
+		fmt.Fprintf(&b, "~ %s %v -> %v\n", e.Word, e.OldSources, e.NewSources)
+	}
+	for _, c := range d.Collapsed {
+		fmt.Fprintf(&b, "= %s (%s)\n", c.Word, strings.Join(c.Spellings, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// String 返回文本格式的差异
+func (d *DictDiff) String() string {
+	var b strings.Builder
+	_ = d.WriteText(&b)
+	return b.String()
+}
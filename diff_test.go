@@ -0,0 +1,98 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiff_ManagerAndFile(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := m.AddWordsWithSource([]string{"保留词", "删除词"}, "custom"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddWordsWithSource([]string{"badword"}, "legacy"); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "next.jsonl")
+	content := strings.Join([]string{
+		`{"word":"保留词","sources":["custom"]}`,
+		`{"word":"BadWord","sources":["custom"]}`,
+		`{"word":"ＢＡＤＷＯＲＤ","sources":["custom"]}`,
+		`{"word":"新增词","sources":["custom"]}`,
+	}, "\n")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := Diff(DictFromManager(m), DictFromFile(path, FormatJSONLines))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(diff.Added, []DiffEntry{{Word: "新增词", NewSources: []string{"custom"}}}) {
+		t.Fatalf("unexpected added: %+v", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []DiffEntry{{Word: "删除词", OldSources: []string{"custom"}}}) {
+		t.Fatalf("unexpected removed: %+v", diff.Removed)
+	}
+	if !reflect.DeepEqual(diff.SourceChanged, []DiffEntry{{Word: "badword", OldSources: []string{"legacy"}, NewSources: []string{"custom"}}}) {
+		t.Fatalf("unexpected source changes: %+v", diff.SourceChanged)
+	}
+	if !reflect.DeepEqual(diff.Collapsed, []DiffCollapse{{Word: "badword", Spellings: []string{"BadWord", "badword", "ＢＡＤＷＯＲＤ"}}}) {
+		t.Fatalf("unexpected collapsed: %+v", diff.Collapsed)
+	}
+
+	text := diff.String()
+	for _, line := range []string{"+ 新增词 [custom]", "- 删除词 [custom]", "~ badword [legacy] -> [custom]"} {
+		if !strings.Contains(text, line) {
+			t.Fatalf("text diff missing %q:\n%s", line, text)
+		}
+	}
+	var buf bytes.Buffer
+	if err := diff.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"source_changed"`) {
+		t.Fatalf("unexpected json diff: %s", buf.String())
+	}
+
+	same, err := Diff(DictFromManager(m), DictFromStore(m.Store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !same.Empty() {
+		t.Fatalf("expect empty diff, got %s", same)
+	}
+}
+
+func TestDiff_DifferentNormalizers(t *testing.T) {
+	// 旧词库区分大小写，新词库忽略大小写：新词库的词从原始写法按旧词库的配置重新归一化
+	sensitive := DefaultNormalizer()
+	sensitive.IgnoreCase = false
+	old := newTestManager(t, FilterOption{Type: FilterAC, Normalizer: &sensitive})
+	if err := old.AddWordsWithSource([]string{"BadWord", "Gone"}, "custom"); err != nil {
+		t.Fatal(err)
+	}
+	next := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := next.AddWordsWithSource([]string{"BadWord", "Fresh"}, "custom"); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := Diff(DictFromManager(old), DictFromManager(next))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(diff.Added, []DiffEntry{{Word: "Fresh", NewSources: []string{"custom"}}}) {
+		t.Fatalf("unexpected added: %+v", diff.Added)
+	}
+	if !reflect.DeepEqual(diff.Removed, []DiffEntry{{Word: "Gone", OldSources: []string{"custom"}}}) {
+		t.Fatalf("unexpected removed: %+v", diff.Removed)
+	}
+	if len(diff.SourceChanged) != 0 || len(diff.Collapsed) != 0 {
+		t.Fatalf("unexpected changes: %+v %+v", diff.SourceChanged, diff.Collapsed)
+	}
+}
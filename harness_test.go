@@ -0,0 +1,108 @@
+package go_sensitive_word
+
+import (
+	"testing"
+	"time"
+)
+
+// waitForSensitive 等待异步监听协程将词同步到过滤器
+func waitForSensitive(t *testing.T, m *Manager, text string) {
+	t.Helper()
+	waitUntil(t, func() bool { return m.IsSensitive(text) }, "filter not updated in time for %q", text)
+}
+
+// waitForRemoved 等待异步监听协程将删除同步到过滤器
+func waitForRemoved(t *testing.T, m *Manager, text string) {
+	t.Helper()
+	waitUntil(t, func() bool { return !m.IsSensitive(text) }, "filter still matches %q", text)
+}
+
+// waitForListener 经增删通道各发送一条探测通知并等待其生效
+// 通道按顺序处理，返回时此前排队的增删通知都已被后台监听协程处理
+func waitForListener(t *testing.T, m *Manager) {
+	t.Helper()
+	const probe = "监听同步探测"
+	if err := m.AddWord(probe); err != nil {
+		t.Fatal(err)
+	}
+	waitForSensitive(t, m, probe)
+	if err := m.DelWord(probe); err != nil {
+		t.Fatal(err)
+	}
+	waitForRemoved(t, m, probe)
+}
+
+// waitUntil 轮询等待 cond 成立，仅用于验证后台监听协程的增量同步本身；
+// 只需要词库生效时使用 newTestManager 或 syncTestFilter 同步载入
+func waitUntil(t *testing.T, cond func() bool, format string, args ...interface{}) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf(format, args...)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// filterName 返回过滤器类型名称，用作子测试名
+func filterName(ft uint32) string {
+	switch ft {
+	case FilterDfa:
+		return "dfa"
+	case FilterAC:
+		return "ac"
+	case FilterDAT:
+		return "dat"
+	}
+	return "unknown"
+}
+
+// allFilters 全部过滤器类型，streamFilters 支持流式匹配的过滤器类型
+var (
+	allFilters    = []uint32{FilterDfa, FilterAC, FilterDAT}
+	streamFilters = []uint32{FilterAC, FilterDAT}
+)
+
+// forEachFilter 以过滤器名称为子测试名，对每种过滤器类型运行 fn，失败信息可直接看出是哪种过滤器
+func forEachFilter(t *testing.T, types []uint32, fn func(t *testing.T, ft uint32)) {
+	t.Helper()
+	for _, ft := range types {
+		ft := ft
+		t.Run(filterName(ft), func(t *testing.T) { fn(t, ft) })
+	}
+}
+
+// newTestManager 创建内存词库的 Manager 并同步载入 words，测试结束时自动关闭
+// 词库经恢复路径在写锁内整体切换并重建匹配结构，返回时即可匹配，无需轮询等待后台监听协程
+func newTestManager(t *testing.T, opt FilterOption, words ...string) *Manager {
+	t.Helper()
+	m, err := NewFilter(StoreOption{Type: StoreMemory}, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Close() })
+	if len(words) > 0 {
+		resetTestEntries(t, m, m.rawEntries(words, ""))
+	}
+	return m
+}
+
+// syncTestFilter 按词库当前的词条同步重建匹配结构，代替轮询等待后台监听协程同步增删
+func syncTestFilter(t *testing.T, m *Manager) {
+	t.Helper()
+	resetTestEntries(t, m, m.Store.GetEntries())
+}
+
+// resetTestEntries 经恢复路径整体替换词条并重建匹配结构
+func resetTestEntries(t *testing.T, m *Manager, entries []DictEntry) {
+	t.Helper()
+	m.restoreMu.Lock()
+	m.allowMu.Lock()
+	err := m.replaceState(entries, m.Normalizer(), m.nf.allow.Load(), 0, nil)
+	m.allowMu.Unlock()
+	m.restoreMu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,9 +1,11 @@
 package ac
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/LuYongwang/go-sensitive-word/internal/filter"
 )
@@ -11,11 +13,12 @@ import (
 type acNode struct {
 	children map[rune]*acNode
 	fail     *acNode
-	output   []string
+	word     string   // 以该节点结尾的词（非终止节点为空）
+	output   []string // 该节点可输出的全部词（自身 + 失败链），由 buildFailurePointer 计算
 }
 
 func newAcNode() *acNode {
-	return &acNode{children: make(map[rune]*acNode), output: make([]string, 0)}
+	return &acNode{children: make(map[rune]*acNode)}
 }
 
 type ACModel struct {
@@ -24,7 +27,18 @@ type ACModel struct {
 	pendingAdds []string               // 待添加的词（窗口合并）
 	pendingDels []string               // 待删除的词（窗口合并）
 	ticker      *time.Ticker           // 窗口计时器
+	fenceAdd    bool                   // 丢弃添加通知直到收到栅栏（由 mu 保护）
+	fenceDel    bool                   // 丢弃删除通知直到收到栅栏（由 mu 保护）
 	done        chan struct{}
+	onFork      atomic.Pointer[func()] // 挂载共享自动机时设置，首次切换根节点时调用
+	gen         atomic.Uint64          // 根节点切换次数
+	maxLen      atomic.Pointer[maxLenCache]
+}
+
+// maxLenCache 根节点对应的最长词长缓存
+type maxLenCache struct {
+	root *acNode
+	n    int
 }
 
 func NewACModel() *ACModel {
@@ -45,33 +59,21 @@ func (m *ACModel) AddWord(word string) {
 
 	root := m.rootPtr.Load()
 	newRoot := m.cloneNode(root)
-
-	now := newRoot
-	for _, r := range word {
-		if next, ok := now.children[r]; ok {
-			now = next
-		} else {
-			next = newAcNode()
-			now.children[r] = next
-			now = next
-		}
-	}
-	now.output = append(now.output, word)
+	insertWord(newRoot, word)
 
 	// 重建失败指针树
 	m.buildFailurePointer(newRoot)
 
 	// 原子切换
-	m.rootPtr.Store(newRoot)
+	m.storeRoot(newRoot)
 }
 
-// cloneNode 深拷贝节点及其子树
+// cloneNode 深拷贝节点及其子树（失败指针与输出由 buildFailurePointer 重新计算）
 func (m *ACModel) cloneNode(n *acNode) *acNode {
 	newNode := &acNode{
-		children: make(map[rune]*acNode),
-		output:   make([]string, len(n.output)),
+		children: make(map[rune]*acNode, len(n.children)),
+		word:     n.word,
 	}
-	copy(newNode.output, n.output)
 	for r, child := range n.children {
 		newNode.children[r] = m.cloneNode(child)
 	}
@@ -102,18 +104,16 @@ func (m *ACModel) DelWord(word string) {
 		}
 		now = next
 	}
-	for i, w := range now.output {
-		if w == word {
-			now.output = append(now.output[:i], now.output[i+1:]...)
-			break
-		}
+	if now.word != word {
+		return // 词不存在
 	}
+	now.word = ""
 
 	// 重建失败指针树
 	m.buildFailurePointer(newRoot)
 
 	// 原子切换
-	m.rootPtr.Store(newRoot)
+	m.storeRoot(newRoot)
 }
 
 func (m *ACModel) Delwords(words ...string) {
@@ -123,10 +123,14 @@ func (m *ACModel) Delwords(words ...string) {
 }
 
 // buildFailurePointer 构建失败指针树（不使用 built 标志，每次重构都重建）
+// 每个节点的输出 = 自身的词 + 失败指针节点的输出（按词长从长到短）
 func (m *ACModel) buildFailurePointer(root *acNode) {
+	root.fail = nil
+	root.output = nil
 	queue := make([]*acNode, 0)
 	for _, child := range root.children {
 		child.fail = root
+		child.output = ownOutput(child)
 		queue = append(queue, child)
 	}
 	for len(queue) > 0 {
@@ -142,10 +146,102 @@ func (m *ACModel) buildFailurePointer(root *acNode) {
 				child.fail = root
 			} else {
 				child.fail = temp.children[char]
-				child.output = append(child.output, child.fail.output...)
 			}
+			child.output = append(ownOutput(child), child.fail.output...)
+		}
+	}
+}
+
+// ownOutput 返回节点自身的输出（仅包含以该节点结尾的词）
+func ownOutput(n *acNode) []string {
+	if n.word == "" {
+		return nil
+	}
+	return []string{n.word}
+}
+
+// Rebuild 使用给定词表整体重建自动机并原子切换，实现 filter.Rebuilder 接口
+// 未刷新的增量变更会被丢弃（以新词表为准）
+func (m *ACModel) Rebuild(words []string) {
+	newRoot := newAcNode()
+	for _, word := range words {
+		insertWord(newRoot, word)
+	}
+	m.buildFailurePointer(newRoot)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pendingAdds != nil {
+		m.pendingAdds = m.pendingAdds[:0]
+		m.pendingDels = m.pendingDels[:0]
+	}
+	m.storeRoot(newRoot)
+}
+
+// storeRoot 原子切换根节点；当前挂载的是共享自动机时视为分叉，通知共享方释放引用
+// 自动机的修改总是基于副本，共享的根节点本身不会被修改
+func (m *ACModel) storeRoot(root *acNode) {
+	m.rootPtr.Store(root)
+	m.gen.Add(1)
+	if fn := m.onFork.Swap(nil); fn != nil {
+		(*fn)()
+	}
+}
+
+// Compiled 已编译的不可变 AC 自动机，可被多个 ACModel 共享
+type Compiled struct {
+	root  *acNode
+	words int
+}
+
+// WordCount 包含的词数
+func (c *Compiled) WordCount() int { return c.words }
+
+// Compile 编译词表为可共享的自动机，实现 filter.Sharer 接口
+func (m *ACModel) Compile(words []string) filter.Compiled {
+	root := newAcNode()
+	for _, word := range words {
+		insertWord(root, word)
+	}
+	m.buildFailurePointer(root)
+	return &Compiled{root: root, words: len(words)}
+}
+
+// Attach 挂载共享自动机（替换当前全部词），实现 filter.Sharer 接口
+// 之后的任何修改都会在副本上进行，首次修改时调用 onFork
+func (m *ACModel) Attach(c filter.Compiled, onFork func()) error {
+	compiled, ok := c.(*Compiled)
+	if !ok {
+		return fmt.Errorf("ac: cannot attach %T", c)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pendingAdds != nil {
+		m.pendingAdds = m.pendingAdds[:0]
+		m.pendingDels = m.pendingDels[:0]
+	}
+	m.storeRoot(compiled.root)
+	if onFork != nil {
+		m.onFork.Store(&onFork)
+	}
+	return nil
+}
+
+// insertWord 将词插入到以 root 为根的字典树
+func insertWord(root *acNode, word string) {
+	if word == "" {
+		return
+	}
+	now := root
+	for _, r := range word {
+		next, ok := now.children[r]
+		if !ok {
+			next = newAcNode()
+			now.children[r] = next
 		}
+		now = next
 	}
+	now.word = word
 }
 
 // Listen 启动监听协程，支持窗口合并（100ms 或 1000 条）
@@ -160,26 +256,20 @@ func (m *ACModel) Listen(addChan, delChan <-chan string) {
 		defer m.ticker.Stop()
 		for {
 			select {
-			case word := <-addChan:
-				if word == "" {
-					continue
+			case word, ok := <-addChan:
+				if !ok { // 存储已关闭
+					m.flushPending()
+					return
 				}
-				m.mu.Lock()
-				m.pendingAdds = append(m.pendingAdds, word)
-				shouldRebuild := len(m.pendingAdds) >= 1000
-				m.mu.Unlock()
-				if shouldRebuild {
+				if m.enqueue(&m.pendingAdds, &m.fenceAdd, word) {
 					m.flushPending()
 				}
-			case word := <-delChan:
-				if word == "" {
-					continue
+			case word, ok := <-delChan:
+				if !ok {
+					m.flushPending()
+					return
 				}
-				m.mu.Lock()
-				m.pendingDels = append(m.pendingDels, word)
-				shouldRebuild := len(m.pendingDels) >= 1000
-				m.mu.Unlock()
-				if shouldRebuild {
+				if m.enqueue(&m.pendingDels, &m.fenceDel, word) {
 					m.flushPending()
 				}
 			case <-m.ticker.C:
@@ -192,18 +282,45 @@ func (m *ACModel) Listen(addChan, delChan <-chan string) {
 	}()
 }
 
+// enqueue 将通道收到的词加入待处理列表，返回是否需要立即刷新
+// 空字符串是栅栏：清除 fenced 标记；栅栏之前（fenced 为 true 时）收到的词已过时，直接丢弃
+func (m *ACModel) enqueue(pending *[]string, fenced *bool, word string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if word == "" {
+		*fenced = false
+		return false
+	}
+	if *fenced {
+		return false
+	}
+	*pending = append(*pending, word)
+	return len(*pending) >= 1000
+}
+
+// Fence 丢弃未刷新的增删并忽略栅栏之前的通知，实现 filter.Fencer 接口
+func (m *ACModel) Fence() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pendingAdds != nil {
+		m.pendingAdds = m.pendingAdds[:0]
+		m.pendingDels = m.pendingDels[:0]
+	}
+	m.fenceAdd, m.fenceDel = true, true
+}
+
 // flushPending 刷新待处理的词（批量应用）
+// 构建与切换都在 mu 内完成，避免与 Rebuild 交错时把旧根节点上的修改发布到新根节点之后
 func (m *ACModel) flushPending() {
 	m.mu.Lock()
+	defer m.mu.Unlock()
 	if len(m.pendingAdds) == 0 && len(m.pendingDels) == 0 {
-		m.mu.Unlock()
 		return
 	}
 	adds := m.pendingAdds
 	dels := m.pendingDels
 	m.pendingAdds = make([]string, 0, 1000)
 	m.pendingDels = make([]string, 0, 1000)
-	m.mu.Unlock()
 
 	// 批量应用
 	root := m.rootPtr.Load()
@@ -215,38 +332,26 @@ func (m *ACModel) flushPending() {
 		for _, r := range word {
 			next, ok := now.children[r]
 			if !ok {
+				now = nil
 				break
 			}
 			now = next
 		}
-		for i, w := range now.output {
-			if w == word {
-				now.output = append(now.output[:i], now.output[i+1:]...)
-				break
-			}
+		if now != nil && now.word == word {
+			now.word = ""
 		}
 	}
 
 	// 再添加
 	for _, word := range adds {
-		now := newRoot
-		for _, r := range word {
-			if next, ok := now.children[r]; ok {
-				now = next
-			} else {
-				next = newAcNode()
-				now.children[r] = next
-				now = next
-			}
-		}
-		now.output = append(now.output, word)
+		insertWord(newRoot, word)
 	}
 
 	// 重建失败指针树
 	m.buildFailurePointer(newRoot)
 
 	// 原子切换
-	m.rootPtr.Store(newRoot)
+	m.storeRoot(newRoot)
 }
 
 func (m *ACModel) FindAll(text string) []string {
@@ -360,15 +465,32 @@ func (m *ACModel) Remove(text string) string {
 	return string(result)
 }
 
-// FindAllRanges 返回所有匹配的区间，实现 RangedFilter 接口
+// FindAllRanges 返回所有匹配的区间（含重复出现与重叠），实现 RangedFilter 接口
+// 区间按结束位置升序，同一结束位置按词长从长到短
 func (m *ACModel) FindAllRanges(text string) []filter.Range {
+	runes := []rune(text)
+	return rangesIn(m.rootPtr.Load(), runes, 0, 0, len(runes))
+}
+
+// FindAllRangesParallel 将长文本分块并发匹配，实现 filter.ParallelRangedFilter 接口
+// 相邻块重叠最长词长 - 1 个字符，结果与 FindAllRanges 完全一致
+func (m *ACModel) FindAllRangesParallel(runes []rune, workers, minChunk int) []filter.Range {
 	root := m.rootPtr.Load()
+	overlap := m.maxWordLen(root) - 1
+	if overlap < 0 {
+		overlap = 0
+	}
+	return filter.ParallelRanges(len(runes), overlap, workers, minChunk, func(from, emitFrom, to int) []filter.Range {
+		return rangesIn(root, runes, from, emitFrom, to)
+	})
+}
+
+// rangesIn 从 runes[from] 处以根节点开始扫描到 to，返回结束位置不小于 emitFrom 的区间
+func rangesIn(root *acNode, runes []rune, from, emitFrom, to int) []filter.Range {
 	var ranges []filter.Range
-	seen := make(map[string]struct{})
 	now := root
-	runes := []rune(text)
-
-	for i, r := range runes {
+	for i := from; i < to; i++ {
+		r := runes[i]
 		for now != root && now.children[r] == nil {
 			now = now.fail
 		}
@@ -377,17 +499,98 @@ func (m *ACModel) FindAllRanges(text string) []filter.Range {
 		} else {
 			now = root
 		}
+		if i < emitFrom {
+			continue
+		}
 		for _, w := range now.output {
-			wordLen := len([]rune(w))
+			wordLen := utf8.RuneCountInString(w)
 			start := i - wordLen + 1
 			if start >= 0 {
-				key := string(runes[start : i+1])
-				if _, ok := seen[key]; !ok {
-					seen[key] = struct{}{}
-					ranges = append(ranges, filter.Range{Start: start, End: i})
-				}
+				ranges = append(ranges, filter.Range{Start: start, End: i})
 			}
 		}
 	}
 	return ranges
 }
+
+// maxWordLen 返回自动机中最长词的长度（rune 数），按根节点缓存
+func (m *ACModel) maxWordLen(root *acNode) int {
+	if c := m.maxLen.Load(); c != nil && c.root == root {
+		return c.n
+	}
+	n := 0
+	var walk func(node *acNode, depth int)
+	walk = func(node *acNode, depth int) {
+		if node.word != "" && depth > n {
+			n = depth
+		}
+		for _, child := range node.children {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+	m.maxLen.Store(&maxLenCache{root: root, n: n})
+	return n
+}
+
+// Snapshot 返回绑定当前自动机的只读过滤器，实现 filter.Snapshotter 接口
+// 自动机的修改总是基于副本，快照无需复制即可保持不变
+func (m *ACModel) Snapshot() filter.Filter {
+	snap := &ACModel{}
+	snap.rootPtr.Store(m.rootPtr.Load())
+	return snap
+}
+
+// acMatcher 逐字符匹配器，持有 Reset 时的根节点（自动机修改总是基于副本，无需加锁）
+type acMatcher struct {
+	m    *ACModel
+	root *acNode
+	node *acNode
+}
+
+// NewMatcher 返回逐字符匹配器，实现 filter.Streamer 接口
+func (m *ACModel) NewMatcher() filter.Matcher {
+	s := &acMatcher{m: m}
+	s.Reset()
+	return s
+}
+
+func (s *acMatcher) Reset() {
+	s.root = s.m.rootPtr.Load()
+	s.node = s.root
+}
+
+func (s *acMatcher) Step(r rune) {
+	for s.node != s.root && s.node.children[r] == nil {
+		s.node = s.node.fail
+	}
+	if next, ok := s.node.children[r]; ok {
+		s.node = next
+	} else {
+		s.node = s.root
+	}
+}
+
+func (s *acMatcher) Output(i int) int {
+	if i < len(s.node.output) {
+		return utf8.RuneCountInString(s.node.output[i])
+	}
+	return 0
+}
+
+func (s *acMatcher) MaxLen() int { return s.m.maxWordLen(s.root) }
+
+// Generation 返回根节点切换次数，实现 filter.PrefixSource 接口
+func (m *ACModel) Generation() uint64 { return m.gen.Load() }
+
+// WalkPrefixes 遍历全部词的前两个字符（字典树前两层），实现 filter.PrefixSource 接口
+func (m *ACModel) WalkPrefixes(fn func(first, second rune)) {
+	for r1, n1 := range m.rootPtr.Load().children {
+		if n1.word != "" {
+			fn(r1, -1)
+		}
+		for r2 := range n1.children {
+			fn(r1, r2)
+		}
+	}
+}
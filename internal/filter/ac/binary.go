@@ -0,0 +1,121 @@
+package ac
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// 节点标记位
+const nodeTerminal = 1 // 以该节点结尾的词存在
+
+// AutomatonKind 匹配结构类型标识，实现 filter.Marshaler 接口
+func (m *ACModel) AutomatonKind() string { return "ac" }
+
+// MarshalAutomaton 使用给定词表构建自动机并编码，实现 filter.Marshaler 接口
+// 编码格式：节点数，随后按 BFS 顺序逐个节点写入 标记、失败指针节点序号、子节点数、子节点字符（升序）；
+// 子节点序号按 BFS 顺序依次分配，无需写入
+func (m *ACModel) MarshalAutomaton(words []string) ([]byte, error) {
+	root := newAcNode()
+	for _, word := range words {
+		insertWord(root, word)
+	}
+	m.buildFailurePointer(root)
+
+	index := map[*acNode]int{root: 0}
+	queue := []*acNode{root}
+	for i := 0; i < len(queue); i++ {
+		for _, r := range sortedRunes(queue[i].children) {
+			child := queue[i].children[r]
+			index[child] = len(queue)
+			queue = append(queue, child)
+		}
+	}
+
+	var w filter.BinaryWriter
+	w.Uvarint(uint64(len(queue)))
+	for _, node := range queue {
+		var flags uint64
+		if node.word != "" {
+			flags |= nodeTerminal
+		}
+		w.Uvarint(flags)
+		fail := 0
+		if node.fail != nil {
+			fail = index[node.fail]
+		}
+		w.Uvarint(uint64(fail))
+		runes := sortedRunes(node.children)
+		w.Uvarint(uint64(len(runes)))
+		for _, r := range runes {
+			w.Uvarint(uint64(r))
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// UnmarshalAutomaton 从编码恢复自动机并原子切换，实现 filter.Marshaler 接口
+// 失败指针直接取自编码数据，输出列表按 BFS 顺序线性计算
+func (m *ACModel) UnmarshalAutomaton(data []byte) error {
+	r := filter.NewBinaryReader(data)
+	count := r.Count()
+	if r.Err() != nil || count == 0 {
+		return fmt.Errorf("ac: %w", filter.ErrCorrupted)
+	}
+	nodes := make([]*acNode, count)
+	paths := make([]string, count)
+	fails := make([]int, count)
+	nodes[0] = newAcNode()
+	next := 1
+	for i := 0; i < count; i++ {
+		node := nodes[i]
+		if node == nil {
+			return fmt.Errorf("ac: %w: node %d unreachable", filter.ErrCorrupted, i)
+		}
+		flags := r.Uvarint()
+		fails[i] = int(r.Uvarint())
+		children := r.Count()
+		if r.Err() != nil || (i > 0 && fails[i] >= i) || next+children > count {
+			return fmt.Errorf("ac: %w at node %d", filter.ErrCorrupted, i)
+		}
+		if flags&nodeTerminal != 0 {
+			node.word = paths[i]
+		}
+		for k := 0; k < children; k++ {
+			ch := rune(r.Uvarint())
+			child := newAcNode()
+			node.children[ch] = child
+			nodes[next] = child
+			paths[next] = paths[i] + string(ch)
+			next++
+		}
+	}
+	if r.Err() != nil || !r.Done() || next != count {
+		return fmt.Errorf("ac: %w", filter.ErrCorrupted)
+	}
+
+	root := nodes[0]
+	for i := 1; i < count; i++ {
+		nodes[i].fail = nodes[fails[i]]
+		nodes[i].output = append(ownOutput(nodes[i]), nodes[i].fail.output...)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.pendingAdds != nil {
+		m.pendingAdds = m.pendingAdds[:0]
+		m.pendingDels = m.pendingDels[:0]
+	}
+	m.storeRoot(root)
+	return nil
+}
+
+func sortedRunes(children map[rune]*acNode) []rune {
+	runes := make([]rune, 0, len(children))
+	for r := range children {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
@@ -0,0 +1,91 @@
+package filter
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrCorrupted 二进制数据损坏或被截断
+var ErrCorrupted = errors.New("corrupted binary data")
+
+// BinaryWriter 以 uvarint 与长度前缀字符串编码数据
+type BinaryWriter struct {
+	buf []byte
+}
+
+// Uvarint 写入无符号变长整数
+func (w *BinaryWriter) Uvarint(v uint64) {
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+// String 写入长度前缀的字符串
+func (w *BinaryWriter) String(s string) {
+	w.Uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// Raw 写入原始字节（不带长度前缀）
+func (w *BinaryWriter) Raw(b []byte) {
+	w.buf = append(w.buf, b...)
+}
+
+// Bytes 返回已写入的数据
+func (w *BinaryWriter) Bytes() []byte { return w.buf }
+
+// BinaryReader 读取 BinaryWriter 编码的数据，出错后后续读取均返回零值，错误通过 Err 获取
+type BinaryReader struct {
+	data []byte
+	off  int
+	err  error
+}
+
+// NewBinaryReader 创建读取器
+func NewBinaryReader(data []byte) *BinaryReader {
+	return &BinaryReader{data: data}
+}
+
+// Uvarint 读取无符号变长整数
+func (r *BinaryReader) Uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data[r.off:])
+	if n <= 0 {
+		r.err = ErrCorrupted
+		return 0
+	}
+	r.off += n
+	return v
+}
+
+// Count 读取元素个数，并校验不超过剩余字节数（每个元素至少占 1 字节），防止超大分配
+func (r *BinaryReader) Count() int {
+	v := r.Uvarint()
+	if r.err == nil && v > uint64(len(r.data)-r.off) {
+		r.err = ErrCorrupted
+		return 0
+	}
+	return int(v)
+}
+
+// String 读取长度前缀的字符串
+func (r *BinaryReader) String() string {
+	return string(r.Bytes())
+}
+
+// Bytes 读取长度前缀的字节切片（引用原数据）
+func (r *BinaryReader) Bytes() []byte {
+	n := r.Count()
+	if r.err != nil {
+		return nil
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+// Err 返回读取过程中的第一个错误
+func (r *BinaryReader) Err() error { return r.err }
+
+// Done 判断数据是否已全部读取
+func (r *BinaryReader) Done() bool { return r.off == len(r.data) }
@@ -0,0 +1,162 @@
+package dat
+
+import (
+	"fmt"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// AutomatonKind 匹配结构类型标识，实现 filter.Marshaler 接口
+func (m *DATModel) AutomatonKind() string { return "dat" }
+
+// MarshalAutomaton 使用给定词表构建双数组并编码，实现 filter.Marshaler 接口
+// 编码格式：字母表（按编码顺序的字符），状态数，随后逐个状态写入 base、check+1、失败指针、词长、输出链接
+func (m *DATModel) MarshalAutomaton(words []string) ([]byte, error) {
+	a := build(words)
+	alphabet := a.runes
+
+	var w filter.BinaryWriter
+	w.Uvarint(uint64(len(alphabet)))
+	for _, r := range alphabet {
+		w.Uvarint(uint64(r))
+	}
+	w.Uvarint(uint64(len(a.check)))
+	for s := range a.check {
+		w.Uvarint(uint64(a.base[s]))
+		w.Uvarint(uint64(a.check[s] + 1))
+		w.Uvarint(uint64(a.fail[s]))
+		w.Uvarint(uint64(a.length[s]))
+		w.Uvarint(uint64(a.dict[s]))
+	}
+	return w.Bytes(), nil
+}
+
+// UnmarshalAutomaton 从编码恢复双数组并原子切换，实现 filter.Marshaler 接口
+// 会校验状态间的父子关系与失败链深度，损坏的数据不会导致越界或死循环
+func (m *DATModel) UnmarshalAutomaton(data []byte) error {
+	r := filter.NewBinaryReader(data)
+	a := &automaton{extra: make(map[rune]uint32)}
+	alphabet := make([]rune, r.Count())
+	maxBMP := rune(-1)
+	for i := range alphabet {
+		alphabet[i] = rune(r.Uvarint())
+		if alphabet[i] < 0x10000 && alphabet[i] > maxBMP {
+			maxBMP = alphabet[i]
+		}
+	}
+	a.runes = alphabet
+	a.codes = make([]uint32, maxBMP+1)
+	for i, c := range alphabet {
+		if c < 0x10000 {
+			a.codes[c] = uint32(i + 1)
+		} else {
+			a.extra[c] = uint32(i + 1)
+		}
+	}
+	n := r.Count()
+	if r.Err() != nil || n == 0 {
+		return fmt.Errorf("dat: %w", filter.ErrCorrupted)
+	}
+	a.base = make([]int32, n)
+	a.check = make([]int32, n)
+	a.fail = make([]int32, n)
+	a.length = make([]int32, n)
+	a.dict = make([]int32, n)
+	for s := 0; s < n && r.Err() == nil; s++ {
+		a.base[s] = int32(r.Uvarint())
+		a.check[s] = int32(r.Uvarint()) - 1
+		a.fail[s] = int32(r.Uvarint())
+		a.length[s] = int32(r.Uvarint())
+		a.dict[s] = int32(r.Uvarint())
+	}
+	if r.Err() != nil || !r.Done() {
+		return fmt.Errorf("dat: %w", filter.ErrCorrupted)
+	}
+	words, err := a.validate(alphabet)
+	if err != nil {
+		return err
+	}
+	a.words = len(words)
+	a.setMaxLen()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.words = make(map[string]struct{}, len(words))
+	for _, w := range words {
+		m.words[w] = struct{}{}
+	}
+	m.pendingAdds = m.pendingAdds[:0]
+	m.pendingDels = m.pendingDels[:0]
+	m.publish(a)
+	return nil
+}
+
+// validate 校验解码后的双数组，返回全部词
+// 要求：每个已占用状态的父状态与编码合法、深度无环；失败指针与输出链接指向更浅的状态；词长等于深度
+func (a *automaton) validate(alphabet []rune) ([]string, error) {
+	n := int32(len(a.check))
+	depth := make([]int32, n) // 0 表示未计算（根节点深度按 0 处理）
+	var depthOf func(s int32, guard int32) (int32, bool)
+	depthOf = func(s int32, guard int32) (int32, bool) {
+		if s == 0 {
+			return 0, true
+		}
+		if depth[s] > 0 {
+			return depth[s], true
+		}
+		p := a.check[s]
+		if p < 0 || p >= n || guard > n {
+			return 0, false
+		}
+		if c := s - a.base[p]; c < 1 || int(c) > len(alphabet) {
+			return 0, false
+		}
+		d, ok := depthOf(p, guard+1)
+		if !ok {
+			return 0, false
+		}
+		depth[s] = d + 1
+		return depth[s], true
+	}
+	for s := int32(1); s < n; s++ {
+		if a.check[s] == -1 {
+			continue
+		}
+		if _, ok := depthOf(s, 0); !ok {
+			return nil, fmt.Errorf("dat: %w: state %d unreachable", filter.ErrCorrupted, s)
+		}
+	}
+	var words []string
+	for s := int32(1); s < n; s++ {
+		if a.check[s] == -1 {
+			if a.length[s] != 0 {
+				return nil, fmt.Errorf("dat: %w: free state %d is terminal", filter.ErrCorrupted, s)
+			}
+			continue
+		}
+		d := depth[s]
+		if f := a.fail[s]; f < 0 || f >= n || (f != 0 && (a.check[f] == -1 || depth[f] == 0 || depth[f] >= d)) {
+			return nil, fmt.Errorf("dat: %w: bad fail link at state %d", filter.ErrCorrupted, s)
+		}
+		if f := a.dict[s]; f < 0 || f >= n || (f != 0 && (a.check[f] == -1 || depth[f] == 0 || depth[f] >= d)) {
+			return nil, fmt.Errorf("dat: %w: bad output link at state %d", filter.ErrCorrupted, s)
+		}
+		if l := a.length[s]; l != 0 && l != d {
+			return nil, fmt.Errorf("dat: %w: bad word length at state %d", filter.ErrCorrupted, s)
+		}
+	}
+	if a.check[0] != -1 || a.length[0] != 0 || a.fail[0] != 0 || a.dict[0] != 0 {
+		return nil, fmt.Errorf("dat: %w: bad root", filter.ErrCorrupted)
+	}
+	for s := int32(1); s < n; s++ {
+		if a.check[s] == -1 || a.length[s] == 0 {
+			continue
+		}
+		runes := make([]rune, a.length[s])
+		for t, i := s, len(runes)-1; t != 0; t, i = a.check[t], i-1 {
+			runes[i] = alphabet[t-a.base[a.check[t]]-1]
+		}
+		words = append(words, string(runes))
+	}
+	return words, nil
+}
@@ -0,0 +1,591 @@
+// Package dat 实现基于双数组（double-array）的 AC 自动机
+// 转移表以连续的 base/check 数组存放，字符先映射为按频率编号的紧凑编码，
+// 相比 map[rune]*node 的字典树分配更少、缓存更友好
+package dat
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// automaton 不可变的双数组 AC 自动机，修改词库时整体重建并原子切换
+// 状态 0 为根节点；check[t] == s 表示 t 是 s 经编码 base[s]+code 转移得到的子状态，-1 表示空闲
+type automaton struct {
+	codes  []uint32        // BMP 字符到编码的映射（0 表示不在字母表中）
+	extra  map[rune]uint32 // BMP 以外字符的编码
+	runes  []rune          // 编码 -> 字符（编码从 1 开始，runes[c-1]）
+	base   []int32
+	check  []int32
+	fail   []int32 // 失败指针
+	length []int32 // 以该状态结尾的词长（rune 数，0 表示非终止状态）
+	dict   []int32 // 失败链上最近的终止状态（0 表示无）
+	words  int
+	maxLen int // 最长词长（rune 数）
+}
+
+// setMaxLen 根据终止状态的词长计算最长词长
+func (a *automaton) setMaxLen() {
+	a.maxLen = 0
+	for _, l := range a.length {
+		if int(l) > a.maxLen {
+			a.maxLen = int(l)
+		}
+	}
+}
+
+func (a *automaton) code(r rune) uint32 {
+	if r >= 0 && int(r) < len(a.codes) {
+		return a.codes[r]
+	}
+	return a.extra[r]
+}
+
+// child 返回状态 s 经编码 c 的子状态
+func (a *automaton) child(s int32, c uint32) (int32, bool) {
+	t := a.base[s] + int32(c)
+	if t >= 0 && int(t) < len(a.check) && a.check[t] == s {
+		return t, true
+	}
+	return 0, false
+}
+
+// next 状态转移：沿失败链查找可转移的状态，不在字母表中的字符直接回到根节点
+func (a *automaton) next(s int32, r rune) int32 {
+	c := a.code(r)
+	if c == 0 {
+		return 0
+	}
+	for {
+		if t, ok := a.child(s, c); ok {
+			return t
+		}
+		if s == 0 {
+			return 0
+		}
+		s = a.fail[s]
+	}
+}
+
+// scan 扫描文本，对每个命中区间调用 fn（按结束位置升序，同一结束位置按词长从长到短）
+// fn 返回 false 时停止
+// 直接遍历字符串，命中即停止的查询无需转换整个文本
+func (a *automaton) scan(text string, fn func(filter.Range) bool) {
+	var s int32
+	i := -1
+	for _, r := range text {
+		i++
+		s = a.next(s, r)
+		out := s
+		if a.length[out] == 0 {
+			out = a.dict[out]
+		}
+		for ; out != 0; out = a.dict[out] {
+			if !fn(filter.Range{Start: i + 1 - int(a.length[out]), End: i}) {
+				return
+			}
+		}
+	}
+}
+
+// rangesIn 从 runes[from] 处以根状态开始扫描到 to，返回结束位置不小于 emitFrom 的区间
+func (a *automaton) rangesIn(runes []rune, from, emitFrom, to int) []filter.Range {
+	var ranges []filter.Range
+	var s int32
+	for i := from; i < to; i++ {
+		s = a.next(s, runes[i])
+		if i < emitFrom {
+			continue
+		}
+		out := s
+		if a.length[out] == 0 {
+			out = a.dict[out]
+		}
+		for ; out != 0; out = a.dict[out] {
+			ranges = append(ranges, filter.Range{Start: i + 1 - int(a.length[out]), End: i})
+		}
+	}
+	return ranges
+}
+
+// ==================== 构建 ====================
+
+type buildEdge struct {
+	r      rune
+	target int
+}
+
+type buildNode struct {
+	edges  []buildEdge
+	length int32
+}
+
+// build 使用词表构建双数组自动机
+func build(words []string) *automaton {
+	sorted := append([]string{}, words...)
+	sort.Strings(sorted)
+
+	// 1. 按字典序插入临时字典树（子节点查找只需比较最后一条边）
+	nodes := []buildNode{{}}
+	freq := make(map[rune]int)
+	count := 0
+	for i, word := range sorted {
+		if word == "" || (i > 0 && word == sorted[i-1]) {
+			continue
+		}
+		count++
+		cur := 0
+		var length int32
+		for _, r := range word {
+			length++
+			edges := nodes[cur].edges
+			if n := len(edges); n > 0 && edges[n-1].r == r {
+				cur = edges[n-1].target
+				continue
+			}
+			nodes = append(nodes, buildNode{})
+			next := len(nodes) - 1
+			nodes[cur].edges = append(nodes[cur].edges, buildEdge{r: r, target: next})
+			freq[r]++
+			cur = next
+		}
+		nodes[cur].length = length
+	}
+
+	// 2. 按出现频率分配编码，高频字符编码小，数组更紧凑
+	alphabet := make([]rune, 0, len(freq))
+	for r := range freq {
+		alphabet = append(alphabet, r)
+	}
+	sort.Slice(alphabet, func(i, j int) bool {
+		if freq[alphabet[i]] != freq[alphabet[j]] {
+			return freq[alphabet[i]] > freq[alphabet[j]]
+		}
+		return alphabet[i] < alphabet[j]
+	})
+	a := &automaton{extra: make(map[rune]uint32), runes: alphabet, words: count}
+	maxBMP := rune(-1)
+	for _, r := range alphabet {
+		if r < 0x10000 && r > maxBMP {
+			maxBMP = r
+		}
+	}
+	a.codes = make([]uint32, maxBMP+1)
+	for i, r := range alphabet {
+		if r < 0x10000 {
+			a.codes[r] = uint32(i + 1)
+		} else {
+			a.extra[r] = uint32(i + 1)
+		}
+	}
+
+	// 3. BFS 放置状态：为每个节点寻找使全部子状态落在空闲位置的 base
+	a.base = []int32{0}
+	a.check = []int32{-1}
+	a.length = []int32{nodes[0].length}
+	state := make([]int32, len(nodes)) // 临时节点 -> 状态
+	order := make([]int, 0, len(nodes))
+	order = append(order, 0)
+	firstFree := 1
+	codes := make([]int32, 0, 16)
+	for i := 0; i < len(order); i++ {
+		u := order[i]
+		edges := nodes[u].edges
+		if len(edges) == 0 {
+			continue
+		}
+		codes = codes[:0]
+		for _, e := range edges {
+			codes = append(codes, int32(a.code(e.r)))
+		}
+		minCode := codes[0]
+		for _, c := range codes {
+			if c < minCode {
+				minCode = c
+			}
+		}
+		for firstFree < len(a.check) && a.check[firstFree] != -1 {
+			firstFree++
+		}
+		b := int32(firstFree) - minCode
+		if b < 1 {
+			b = 1
+		}
+		for !a.fits(b, codes) {
+			b++
+		}
+		s := state[u]
+		a.base[s] = b
+		for k, e := range edges {
+			t := b + codes[k]
+			a.grow(int(t) + 1)
+			a.check[t] = s
+			a.length[t] = nodes[e.target].length
+			state[e.target] = t
+			order = append(order, e.target)
+		}
+	}
+
+	// 4. 按 BFS 顺序计算失败指针与输出链接
+	a.fail = make([]int32, len(a.check))
+	a.dict = make([]int32, len(a.check))
+	for _, u := range order {
+		s := state[u]
+		for _, e := range nodes[u].edges {
+			t := state[e.target]
+			c := a.code(e.r)
+			if s != 0 {
+				for f := a.fail[s]; ; f = a.fail[f] {
+					if next, ok := a.child(f, c); ok {
+						a.fail[t] = next
+						break
+					}
+					if f == 0 {
+						break
+					}
+				}
+			}
+			if f := a.fail[t]; a.length[f] > 0 {
+				a.dict[t] = f
+			} else {
+				a.dict[t] = a.dict[f]
+			}
+		}
+	}
+	a.setMaxLen()
+	return a
+}
+
+// fits 判断以 b 为 base 时全部子状态位置是否空闲
+func (a *automaton) fits(b int32, codes []int32) bool {
+	for _, c := range codes {
+		t := int(b + c)
+		if t < len(a.check) && a.check[t] != -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// grow 扩展数组到至少 n 个状态
+func (a *automaton) grow(n int) {
+	for len(a.check) < n {
+		a.base = append(a.base, 0)
+		a.check = append(a.check, -1)
+		a.length = append(a.length, 0)
+	}
+}
+
+// ==================== 过滤器 ====================
+
+// DATModel 基于双数组 AC 自动机的过滤器
+// 双数组不便于原地修改，词库变更按窗口合并后整体重建并原子切换（读取无锁）
+type DATModel struct {
+	ptr         atomic.Pointer[automaton]
+	mu          sync.Mutex          // 保护 words 与构建过程
+	words       map[string]struct{} // 当前词表
+	pendingAdds []string            // 待添加的词（窗口合并）
+	pendingDels []string            // 待删除的词（窗口合并）
+	fenceAdd    bool                // 丢弃添加通知直到收到栅栏（由 mu 保护）
+	fenceDel    bool                // 丢弃删除通知直到收到栅栏（由 mu 保护）
+	done        chan struct{}
+	gen         atomic.Uint64 // 自动机切换次数
+}
+
+func NewDATModel() *DATModel {
+	m := &DATModel{
+		words: make(map[string]struct{}),
+		done:  make(chan struct{}),
+	}
+	m.publish(build(nil))
+	return m
+}
+
+// publish 原子切换自动机
+func (m *DATModel) publish(a *automaton) {
+	m.ptr.Store(a)
+	m.gen.Add(1)
+}
+
+// rebuildLocked 使用当前词表重建自动机并原子切换，调用方需持有 mu
+func (m *DATModel) rebuildLocked() {
+	words := make([]string, 0, len(m.words))
+	for w := range m.words {
+		words = append(words, w)
+	}
+	m.publish(build(words))
+}
+
+func (m *DATModel) AddWord(word string) {
+	if word == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.words[word] = struct{}{}
+	m.rebuildLocked()
+}
+
+func (m *DATModel) DelWord(word string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.words[word]; !ok {
+		return
+	}
+	delete(m.words, word)
+	m.rebuildLocked()
+}
+
+// Rebuild 使用完整词表整体重建并原子切换，实现 filter.Rebuilder 接口
+func (m *DATModel) Rebuild(words []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.words = make(map[string]struct{}, len(words))
+	for _, w := range words {
+		if w != "" {
+			m.words[w] = struct{}{}
+		}
+	}
+	m.pendingAdds = m.pendingAdds[:0]
+	m.pendingDels = m.pendingDels[:0]
+	m.rebuildLocked()
+}
+
+// Listen 启动监听协程，支持窗口合并（100ms 或 1000 条），每个窗口只重建一次
+func (m *DATModel) Listen(addChan, delChan <-chan string) {
+	ticker := time.NewTicker(100 * time.Millisecond) // 100ms 窗口
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case word, ok := <-addChan:
+				if !ok { // 存储已关闭
+					m.flushPending()
+					return
+				}
+				if m.enqueue(&m.pendingAdds, &m.fenceAdd, word) {
+					m.flushPending()
+				}
+			case word, ok := <-delChan:
+				if !ok {
+					m.flushPending()
+					return
+				}
+				if m.enqueue(&m.pendingDels, &m.fenceDel, word) {
+					m.flushPending()
+				}
+			case <-ticker.C:
+				m.flushPending()
+			case <-m.done:
+				m.flushPending() // 最后刷新一次
+				return
+			}
+		}
+	}()
+}
+
+// enqueue 将通道收到的词加入待处理列表，返回是否需要立即重建
+// 空字符串是栅栏：清除 fenced 标记；栅栏之前（fenced 为 true 时）收到的词已过时，直接丢弃
+func (m *DATModel) enqueue(pending *[]string, fenced *bool, word string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if word == "" {
+		*fenced = false
+		return false
+	}
+	if *fenced {
+		return false
+	}
+	*pending = append(*pending, word)
+	return len(*pending) >= 1000
+}
+
+// Fence 丢弃未应用的增删并忽略栅栏之前的通知，实现 filter.Fencer 接口
+func (m *DATModel) Fence() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pendingAdds = m.pendingAdds[:0]
+	m.pendingDels = m.pendingDels[:0]
+	m.fenceAdd, m.fenceDel = true, true
+}
+
+// flushPending 批量应用待处理的词（先删除再添加）并重建一次
+func (m *DATModel) flushPending() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.pendingAdds) == 0 && len(m.pendingDels) == 0 {
+		return
+	}
+	for _, w := range m.pendingDels {
+		delete(m.words, w)
+	}
+	for _, w := range m.pendingAdds {
+		m.words[w] = struct{}{}
+	}
+	m.pendingAdds = m.pendingAdds[:0]
+	m.pendingDels = m.pendingDels[:0]
+	m.rebuildLocked()
+}
+
+// Snapshot 返回绑定当前自动机的只读过滤器，实现 filter.Snapshotter 接口
+// 自动机不可变，快照无需复制
+func (m *DATModel) Snapshot() filter.Filter {
+	snap := &DATModel{}
+	snap.ptr.Store(m.ptr.Load())
+	return snap
+}
+
+// FindAllRanges 返回所有匹配的区间（含重复出现与重叠），实现 RangedFilter 接口
+// 区间按结束位置升序，同一结束位置按词长从长到短
+func (m *DATModel) FindAllRanges(text string) []filter.Range {
+	var ranges []filter.Range
+	m.ptr.Load().scan(text, func(r filter.Range) bool {
+		ranges = append(ranges, r)
+		return true
+	})
+	return ranges
+}
+
+// FindAllRangesParallel 将长文本分块并发匹配，实现 filter.ParallelRangedFilter 接口
+// 相邻块重叠最长词长 - 1 个字符，结果与 FindAllRanges 完全一致
+func (m *DATModel) FindAllRangesParallel(runes []rune, workers, minChunk int) []filter.Range {
+	a := m.ptr.Load()
+	overlap := a.maxLen - 1
+	if overlap < 0 {
+		overlap = 0
+	}
+	return filter.ParallelRanges(len(runes), overlap, workers, minChunk, func(from, emitFrom, to int) []filter.Range {
+		return a.rangesIn(runes, from, emitFrom, to)
+	})
+}
+
+func (m *DATModel) FindAll(text string) []string {
+	runes := []rune(text)
+	var matches []string
+	seen := make(map[string]struct{})
+	m.ptr.Load().scan(text, func(r filter.Range) bool {
+		word := string(runes[r.Start : r.End+1])
+		if _, ok := seen[word]; !ok {
+			seen[word] = struct{}{}
+			matches = append(matches, word)
+		}
+		return true
+	})
+	return matches
+}
+
+func (m *DATModel) FindAllCount(text string) map[string]int {
+	runes := []rune(text)
+	counts := make(map[string]int)
+	m.ptr.Load().scan(text, func(r filter.Range) bool {
+		counts[string(runes[r.Start:r.End+1])]++
+		return true
+	})
+	return counts
+}
+
+// FindOne 返回第一个命中位置上最长的词
+func (m *DATModel) FindOne(text string) string {
+	runes := []rune(text)
+	word := ""
+	m.ptr.Load().scan(text, func(r filter.Range) bool {
+		word = string(runes[r.Start : r.End+1])
+		return false
+	})
+	return word
+}
+
+func (m *DATModel) IsSensitive(text string) bool {
+	found := false
+	m.ptr.Load().scan(text, func(filter.Range) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+func (m *DATModel) Replace(text string, repl rune) string {
+	runes := []rune(text)
+	m.ptr.Load().scan(text, func(r filter.Range) bool {
+		for i := r.Start; i <= r.End; i++ {
+			runes[i] = repl
+		}
+		return true
+	})
+	return string(runes)
+}
+
+func (m *DATModel) Remove(text string) string {
+	runes := []rune(text)
+	toDelete := make([]bool, len(runes))
+	m.ptr.Load().scan(text, func(r filter.Range) bool {
+		for i := r.Start; i <= r.End; i++ {
+			toDelete[i] = true
+		}
+		return true
+	})
+	result := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if !toDelete[i] {
+			result = append(result, r)
+		}
+	}
+	return string(result)
+}
+
+// datMatcher 逐字符匹配器，持有 Reset 时的自动机（自动机不可变，无需加锁）
+type datMatcher struct {
+	m *DATModel
+	a *automaton
+	s int32
+}
+
+// NewMatcher 返回逐字符匹配器，实现 filter.Streamer 接口
+func (m *DATModel) NewMatcher() filter.Matcher {
+	s := &datMatcher{m: m}
+	s.Reset()
+	return s
+}
+
+func (s *datMatcher) Reset() {
+	s.a = s.m.ptr.Load()
+	s.s = 0
+}
+
+func (s *datMatcher) Step(r rune) { s.s = s.a.next(s.s, r) }
+
+func (s *datMatcher) MaxLen() int { return s.a.maxLen }
+
+func (s *datMatcher) Output(i int) int {
+	out := s.s
+	if s.a.length[out] == 0 {
+		out = s.a.dict[out]
+	}
+	for ; i > 0 && out != 0; i-- {
+		out = s.a.dict[out]
+	}
+	return int(s.a.length[out])
+}
+
+// Generation 返回自动机切换次数，实现 filter.PrefixSource 接口
+func (m *DATModel) Generation() uint64 { return m.gen.Load() }
+
+// WalkPrefixes 遍历全部词的前两个字符（深度 1、2 的状态），实现 filter.PrefixSource 接口
+func (m *DATModel) WalkPrefixes(fn func(first, second rune)) {
+	a := m.ptr.Load()
+	label := func(t int32) rune { return a.runes[t-a.base[a.check[t]]-1] }
+	for t := int32(1); t < int32(len(a.check)); t++ {
+		p := a.check[t]
+		switch {
+		case p == 0:
+			if a.length[t] > 0 {
+				fn(label(t), -1)
+			}
+		case p > 0 && a.check[p] == 0:
+			fn(label(p), label(t))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package dfa
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// 节点标记位
+const nodeLeaf = 1 // 以该节点结尾的词存在
+
+// AutomatonKind 匹配结构类型标识，实现 filter.Marshaler 接口
+func (m *DFAModel) AutomatonKind() string { return "dfa" }
+
+// MarshalAutomaton 使用给定词表构建字典树并编码，实现 filter.Marshaler 接口
+// 编码格式：节点数，随后按 BFS 顺序逐个节点写入 标记、子节点数、子节点字符（升序）；
+// 子节点序号按 BFS 顺序依次分配，无需写入
+func (m *DFAModel) MarshalAutomaton(words []string) ([]byte, error) {
+	root := newDfaNode()
+	for _, word := range words {
+		insertWord(root, word)
+	}
+	queue := []*dfaNode{root}
+	for i := 0; i < len(queue); i++ {
+		for _, r := range sortedRunes(queue[i].children) {
+			queue = append(queue, queue[i].children[r])
+		}
+	}
+
+	var w filter.BinaryWriter
+	w.Uvarint(uint64(len(queue)))
+	for _, node := range queue {
+		var flags uint64
+		if node.isLeaf {
+			flags |= nodeLeaf
+		}
+		w.Uvarint(flags)
+		runes := sortedRunes(node.children)
+		w.Uvarint(uint64(len(runes)))
+		for _, r := range runes {
+			w.Uvarint(uint64(r))
+		}
+	}
+	return w.Bytes(), nil
+}
+
+// UnmarshalAutomaton 从编码恢复字典树并原子切换，实现 filter.Marshaler 接口
+func (m *DFAModel) UnmarshalAutomaton(data []byte) error {
+	r := filter.NewBinaryReader(data)
+	count := r.Count()
+	if r.Err() != nil || count == 0 {
+		return fmt.Errorf("dfa: %w", filter.ErrCorrupted)
+	}
+	nodes := make([]*dfaNode, count)
+	nodes[0] = newDfaNode()
+	next := 1
+	for i := 0; i < count; i++ {
+		node := nodes[i]
+		if node == nil {
+			return fmt.Errorf("dfa: %w: node %d unreachable", filter.ErrCorrupted, i)
+		}
+		node.isLeaf = r.Uvarint()&nodeLeaf != 0
+		children := r.Count()
+		if r.Err() != nil || next+children > count {
+			return fmt.Errorf("dfa: %w at node %d", filter.ErrCorrupted, i)
+		}
+		for k := 0; k < children; k++ {
+			child := newDfaNode()
+			node.children[rune(r.Uvarint())] = child
+			nodes[next] = child
+			next++
+		}
+	}
+	if r.Err() != nil || !r.Done() || next != count {
+		return fmt.Errorf("dfa: %w", filter.ErrCorrupted)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gen.Add(1)
+	m.rootPtr.Store(nodes[0])
+	m.frozen = false
+	m.releaseShared()
+	return nil
+}
+
+func sortedRunes(children map[rune]*dfaNode) []rune {
+	runes := make([]rune, 0, len(children))
+	for r := range children {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
@@ -1,6 +1,13 @@
 package dfa
 
-import "github.com/LuYongwang/go-sensitive-word/internal/filter"
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
 
 type dfaNode struct {
 	children map[rune]*dfaNode
@@ -12,11 +19,20 @@ func newDfaNode() *dfaNode {
 }
 
 type DFAModel struct {
-	root *dfaNode
+	rootPtr atomic.Pointer[dfaNode] // 原子指针，支持 Rebuild 时整体切换
+	mu      sync.RWMutex            // 字典树原地修改时持有写锁，查询时持有读锁
+	onFork  atomic.Pointer[func()]  // 挂载共享字典树时设置，首次修改前分叉并调用
+	frozen  bool                    // 当前字典树已被快照引用，修改前需复制（由 mu 保护）
+	gen     atomic.Uint64           // 修改次数（修改前递增）
+
+	fenceAdd bool // 丢弃添加通知直到收到栅栏（由 mu 保护）
+	fenceDel bool // 丢弃删除通知直到收到栅栏（由 mu 保护）
 }
 
 func NewDFAModel() *DFAModel {
-	return &DFAModel{root: newDfaNode()}
+	model := &DFAModel{}
+	model.rootPtr.Store(newDfaNode())
+	return model
 }
 
 func (m *DFAModel) AddWords(words ...string) {
@@ -29,17 +45,15 @@ func (m *DFAModel) AddWord(word string) {
 	if word == "" {
 		return
 	}
-	now := m.root
-	for _, r := range word {
-		if next, ok := now.children[r]; ok {
-			now = next
-		} else {
-			next = newDfaNode()
-			now.children[r] = next
-			now = next
-		}
-	}
-	now.isLeaf = true
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.addLocked(word)
+}
+
+// addLocked 添加词，调用方需持有 mu 写锁
+func (m *DFAModel) addLocked(word string) {
+	m.gen.Add(1)
+	insertWord(m.writableRoot(), word)
 }
 
 func (m *DFAModel) DelWords(words ...string) {
@@ -52,13 +66,22 @@ func (m *DFAModel) DelWord(word string) {
 	if word == "" {
 		return
 	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.delLocked(word)
+}
+
+// delLocked 删除词，调用方需持有 mu 写锁
+func (m *DFAModel) delLocked(word string) {
+	m.gen.Add(1)
+	root := m.writableRoot()
 	runes := []rune(word)
 	type pathElem struct {
 		node *dfaNode
 		ch   rune
 	}
 	path := make([]pathElem, 0, len(runes)+1)
-	now := m.root
+	now := root
 	path = append(path, pathElem{node: now})
 	for _, r := range runes {
 		next, ok := now.children[r]
@@ -84,31 +107,154 @@ func (m *DFAModel) DelWord(word string) {
 	}
 }
 
-func (m *DFAModel) Listen(addChan, delChan <-chan string) {
-	go func() {
-		for word := range addChan {
-			m.AddWord(word)
+// Rebuild 使用给定词表整体重建字典树并原子切换，实现 filter.Rebuilder 接口
+func (m *DFAModel) Rebuild(words []string) {
+	root := newDfaNode()
+	for _, word := range words {
+		insertWord(root, word)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gen.Add(1)
+	m.rootPtr.Store(root)
+	m.frozen = false
+	m.releaseShared()
+}
+
+// writableRoot 返回可原地修改的根节点，调用方需持有 mu
+// 当前字典树是共享的或已被快照引用时，先复制一份私有副本（分叉）
+func (m *DFAModel) writableRoot() *dfaNode {
+	root := m.rootPtr.Load()
+	if m.onFork.Load() == nil && !m.frozen {
+		return root
+	}
+	root = cloneDfaNode(root)
+	m.rootPtr.Store(root)
+	m.frozen = false
+	m.releaseShared()
+	return root
+}
+
+// Snapshot 返回绑定当前字典树的只读过滤器，实现 filter.Snapshotter 接口
+// 获取快照本身不复制字典树，之后的首次修改才复制一份
+func (m *DFAModel) Snapshot() filter.Filter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.frozen = true
+	snap := &DFAModel{frozen: true}
+	snap.rootPtr.Store(m.rootPtr.Load())
+	return snap
+}
+
+// releaseShared 不再使用共享字典树时通知共享方释放引用
+func (m *DFAModel) releaseShared() {
+	if fn := m.onFork.Swap(nil); fn != nil {
+		(*fn)()
+	}
+}
+
+func cloneDfaNode(n *dfaNode) *dfaNode {
+	c := &dfaNode{children: make(map[rune]*dfaNode, len(n.children)), isLeaf: n.isLeaf}
+	for r, child := range n.children {
+		c.children[r] = cloneDfaNode(child)
+	}
+	return c
+}
+
+// Compiled 已编译的不可变字典树，可被多个 DFAModel 共享
+type Compiled struct {
+	root  *dfaNode
+	words int
+}
+
+// WordCount 包含的词数
+func (c *Compiled) WordCount() int { return c.words }
+
+// Compile 编译词表为可共享的字典树，实现 filter.Sharer 接口
+func (m *DFAModel) Compile(words []string) filter.Compiled {
+	root := newDfaNode()
+	for _, word := range words {
+		insertWord(root, word)
+	}
+	return &Compiled{root: root, words: len(words)}
+}
+
+// Attach 挂载共享字典树（替换当前全部词），实现 filter.Sharer 接口
+// 首次修改前会复制私有副本并调用 onFork
+func (m *DFAModel) Attach(c filter.Compiled, onFork func()) error {
+	compiled, ok := c.(*Compiled)
+	if !ok {
+		return fmt.Errorf("dfa: cannot attach %T", c)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.releaseShared()
+	m.gen.Add(1)
+	m.rootPtr.Store(compiled.root)
+	m.frozen = false
+	if onFork != nil {
+		m.onFork.Store(&onFork)
+	}
+	return nil
+}
+
+// insertWord 将词插入到以 root 为根的字典树
+func insertWord(root *dfaNode, word string) {
+	if word == "" {
+		return
+	}
+	now := root
+	for _, r := range word {
+		next, ok := now.children[r]
+		if !ok {
+			next = newDfaNode()
+			now.children[r] = next
 		}
-	}()
-	go func() {
-		for word := range delChan {
-			m.DelWord(word)
+		now = next
+	}
+	now.isLeaf = true
+}
+
+// Fence 忽略栅栏之前的增删通知，实现 filter.Fencer 接口
+func (m *DFAModel) Fence() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fenceAdd, m.fenceDel = true, true
+}
+
+// Listen 启动监听协程，逐条应用增删通知
+// 空字符串是栅栏：清除对应的 fence 标记；栅栏之前收到的词已过时，直接丢弃
+func (m *DFAModel) Listen(addChan, delChan <-chan string) {
+	apply := func(ch <-chan string, fenced *bool, fn func(string)) {
+		for word := range ch {
+			m.mu.Lock()
+			if word == "" {
+				*fenced = false
+			} else if !*fenced {
+				fn(word)
+			}
+			m.mu.Unlock()
 		}
-	}()
+	}
+	go apply(addChan, &m.fenceAdd, m.addLocked)
+	go apply(delChan, &m.fenceDel, m.delLocked)
 }
 
 func (m *DFAModel) FindAll(text string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	root := m.rootPtr.Load()
 	var matches []string
 	var found bool
 	var now *dfaNode
 	start := 0
-	parent := m.root
+	parent := root
 	runes := []rune(text)
 	length := len(runes)
 	for pos := 0; pos < length; pos++ {
 		now, found = parent.children[runes[pos]]
 		if !found {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
@@ -117,7 +263,7 @@ func (m *DFAModel) FindAll(text string) []string {
 			matches = append(matches, string(runes[start:pos+1]))
 		}
 		if pos == length-1 {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
@@ -136,17 +282,20 @@ func (m *DFAModel) FindAll(text string) []string {
 }
 
 func (m *DFAModel) FindAllCount(text string) map[string]int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	root := m.rootPtr.Load()
 	res := make(map[string]int)
 	var found bool
 	var now *dfaNode
 	start := 0
-	parent := m.root
+	parent := root
 	runes := []rune(text)
 	length := len(runes)
 	for pos := 0; pos < length; pos++ {
 		now, found = parent.children[runes[pos]]
 		if !found {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
@@ -155,7 +304,7 @@ func (m *DFAModel) FindAllCount(text string) map[string]int {
 			res[string(runes[start:pos+1])]++
 		}
 		if pos == length-1 {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
@@ -166,16 +315,19 @@ func (m *DFAModel) FindAllCount(text string) map[string]int {
 }
 
 func (m *DFAModel) FindOne(text string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	root := m.rootPtr.Load()
 	var found bool
 	var now *dfaNode
 	start := 0
-	parent := m.root
+	parent := root
 	runes := []rune(text)
 	length := len(runes)
 	for pos := 0; pos < length; pos++ {
 		now, found = parent.children[runes[pos]]
 		if !found || (!now.isLeaf && pos == length-1) {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
@@ -190,54 +342,70 @@ func (m *DFAModel) FindOne(text string) string {
 
 func (m *DFAModel) IsSensitive(text string) bool { return m.FindOne(text) != "" }
 
-// FindAllRanges 返回所有匹配的区间，实现 RangedFilter 接口
+// FindAllRanges 返回所有匹配的区间（含重复出现与重叠），实现 RangedFilter 接口
+// 区间按起始位置升序，同一起始位置按词长从短到长
 func (m *DFAModel) FindAllRanges(text string) []filter.Range {
+	ranges, _ := m.FindAllRangesContext(context.Background(), text)
+	return ranges
+}
+
+// ctxCheckInterval 每推进多少个起始位置检查一次 ctx
+const ctxCheckInterval = 1024
+
+// FindAllRangesContext 同 FindAllRanges，定期检查 ctx，取消时返回已找到的区间与 ctx.Err()
+func (m *DFAModel) FindAllRangesContext(ctx context.Context, text string) ([]filter.Range, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	root := m.rootPtr.Load()
 	var ranges []filter.Range
 	var found bool
 	var now *dfaNode
 	start := 0
-	parent := m.root
+	parent := root
 	runes := []rune(text)
 	length := len(runes)
-	seen := make(map[string]struct{}) // 用字符串作为 key 去重
 
 	for pos := 0; pos < length; pos++ {
+		if pos == start && start%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return ranges, err
+			}
+		}
 		now, found = parent.children[runes[pos]]
 		if !found {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
 		}
 		if now.isLeaf && start <= pos {
-			key := string(runes[start : pos+1])
-			if _, exists := seen[key]; !exists {
-				seen[key] = struct{}{}
-				ranges = append(ranges, filter.Range{Start: start, End: pos})
-			}
+			ranges = append(ranges, filter.Range{Start: start, End: pos})
 		}
 		if pos == length-1 {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
 		}
 		parent = now
 	}
-	return ranges
+	return ranges, nil
 }
 
 func (m *DFAModel) Replace(text string, repl rune) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	root := m.rootPtr.Load()
 	var found bool
 	var now *dfaNode
 	start := 0
-	parent := m.root
+	parent := root
 	runes := []rune(text)
 	length := len(runes)
 	for pos := 0; pos < length; pos++ {
 		now, found = parent.children[runes[pos]]
 		if !found || (!now.isLeaf && pos == length-1) {
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
@@ -253,10 +421,13 @@ func (m *DFAModel) Replace(text string, repl rune) string {
 }
 
 func (m *DFAModel) Remove(text string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	root := m.rootPtr.Load()
 	var found bool
 	var now *dfaNode
 	start := 0
-	parent := m.root
+	parent := root
 	runes := []rune(text)
 	length := len(runes)
 	filtered := make([]rune, 0, length)
@@ -264,14 +435,14 @@ func (m *DFAModel) Remove(text string) string {
 		now, found = parent.children[runes[pos]]
 		if !found || (!now.isLeaf && pos == length-1) {
 			filtered = append(filtered, runes[start])
-			parent = m.root
+			parent = root
 			pos = start
 			start++
 			continue
 		}
 		if now.isLeaf {
 			start = pos + 1
-			parent = m.root
+			parent = root
 		} else {
 			parent = now
 		}
@@ -279,3 +450,21 @@ func (m *DFAModel) Remove(text string) string {
 	filtered = append(filtered, runes[start:]...)
 	return string(filtered)
 }
+
+// Generation 返回字典树修改次数，实现 filter.PrefixSource 接口
+func (m *DFAModel) Generation() uint64 { return m.gen.Load() }
+
+// WalkPrefixes 遍历全部词的前两个字符（字典树前两层），实现 filter.PrefixSource 接口
+// 字典树可能被原地修改，遍历期间持有读锁
+func (m *DFAModel) WalkPrefixes(fn func(first, second rune)) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for r1, n1 := range m.rootPtr.Load().children {
+		if n1.isLeaf {
+			fn(r1, -1)
+		}
+		for r2 := range n1.children {
+			fn(r1, r2)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package filter
 
+import "context"
+
 // Range 表示匹配区间 [Start, End]，闭区间
 type Range struct {
 	Start int // 起始位置（包含）
@@ -8,16 +10,89 @@ type Range struct {
 
 // MatchResult 包含匹配词和其来源信息
 type MatchResult struct {
-	Word   string   // 匹配到的敏感词
-	Source []string // 该词所属的词库来源列表
+	Word      string   // 匹配到的敏感词
+	Source    []string // 该词所属的词库来源列表
+	Original  string   // 词库中该词的规范原始写法（归一化之前）
+	Canonical string   // 所属别名组的规范词（不属于别名组时为匹配的词本身）
+	Variant   string   // 实际匹配到的词库中的词（别名组中的变体）
 }
 
 // RangedFilter 是可选的扩展接口，返回匹配区间而非字符串
+// 返回全部出现位置（同一个词多次出现会返回多个区间）
 // 如果实现类支持此接口，包装器会优先使用以提高性能
 type RangedFilter interface {
 	FindAllRanges(text string) []Range
 }
 
+// Rebuilder 是可选的扩展接口，使用完整词表整体重建匹配结构并原子切换
+// 用于恢复备份等需要一次性替换整个词库的场景
+type Rebuilder interface {
+	Rebuild(words []string)
+}
+
+// Fencer 是可选的扩展接口：整体重建前调用 Fence，丢弃尚未应用的增删通知，
+// 并忽略之后从各通道收到的通知，直到该通道收到一个空字符串（词库在重建完成后发出的栅栏）
+// 用于保证重建前已排队的过时通知不会叠加到新的匹配结构上
+type Fencer interface {
+	Fence()
+}
+
+// Compiled 已编译的不可变匹配结构，可被多个过滤器实例共享
+type Compiled interface {
+	WordCount() int // 包含的词数
+}
+
+// Sharer 是可选的扩展接口：编译可共享的匹配结构，并以写时分叉的方式挂载
+// 挂载后首次修改词库时，过滤器会复制一份私有结构并调用 onFork（用于释放共享引用）
+type Sharer interface {
+	Compile(words []string) Compiled
+	Attach(c Compiled, onFork func()) error
+}
+
+// Snapshotter 是可选的扩展接口：返回绑定当前匹配结构的只读过滤器
+// 之后的词库变更不会影响已返回的快照
+type Snapshotter interface {
+	Snapshot() Filter
+}
+
+// Marshaler 是可选的扩展接口：将构建完成的匹配结构（含失败指针等）编码为二进制，
+// 并可直接从二进制恢复，无需重新构建
+type Marshaler interface {
+	AutomatonKind() string                           // 匹配结构类型标识（如 "ac"、"dfa"）
+	MarshalAutomaton(words []string) ([]byte, error) // 使用给定词表构建并编码（不修改当前过滤器）
+	UnmarshalAutomaton(data []byte) error            // 从编码恢复并原子切换
+}
+
+// Matcher 逐字符推进的匹配状态，绑定某一时刻的匹配结构，不可并发使用
+type Matcher interface {
+	Reset()           // 回到初始状态，并重新绑定过滤器当前的匹配结构
+	Step(r rune)      // 读入一个字符
+	Output(i int) int // 当前状态的第 i 个输出（按词长从长到短）的词长（rune 数），没有更多输出时返回 0
+	MaxLen() int      // 绑定的匹配结构中最长词的长度（rune 数）
+}
+
+// Streamer 是可选的扩展接口：返回逐字符匹配器，调用方可边读取文本边推进匹配，
+// 无需先构造完整的文本；匹配器可复用（Reset 后绑定最新的匹配结构）
+type Streamer interface {
+	NewMatcher() Matcher
+}
+
+// ParallelRangedFilter 是可选的扩展接口：将长文本分块并发匹配，结果与 FindAllRanges 完全一致
+type ParallelRangedFilter interface {
+	FindAllRangesParallel(runes []rune, workers, minChunk int) []Range
+}
+
+// ContextRangedFilter 是可选的扩展接口：匹配过程中定期检查 ctx，取消时返回已找到的区间与 ctx.Err()
+type ContextRangedFilter interface {
+	FindAllRangesContext(ctx context.Context, text string) ([]Range, error)
+}
+
+// PrefixSource 是可选的扩展接口：提供匹配结构的版本号与全部词的前两个字符，用于构建预过滤器
+type PrefixSource interface {
+	Generation() uint64                       // 匹配结构版本，每次发布（切换或修改）时变化
+	WalkPrefixes(fn func(first, second rune)) // 遍历全部词的前两个字符，单字符词的 second 为 -1
+}
+
 type (
 	Filter interface {
 		FindAll(text string) []string
@@ -0,0 +1,389 @@
+// Package flat 实现只读的扁平 AC 自动机：节点与边以定长记录存放在连续字节中，
+// 可直接在内存映射的文件上匹配，无需反序列化，多个进程可共享同一份页缓存
+package flat
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// 文件布局（小端序）：
+//
+//	[0:4)   魔数 "GSWF"
+//	[4:8)   格式版本
+//	[8:12)  节点数 N
+//	[12:16) 边数 E
+//	[16:20) 元数据长度 M
+//	[20:24) 头部之后全部数据的 CRC32
+//	[24:32) 保留
+//	元数据 M 字节（填充到 4 字节对齐）
+//	节点表 N × 20 字节：边起始序号、边数、失败指针、词长（rune 数，0 表示非终止节点）、输出链接
+//	边表   E × 8 字节：字符、目标节点（同一节点的边按字符升序）
+//
+// 节点按 BFS 顺序编号，失败指针与输出链接总是指向序号更小的节点；
+// 输出链接指向失败链上最近的终止节点（0 表示无）
+const (
+	magic         = "GSWF"
+	formatVersion = 1
+	headerSize    = 32
+	nodeSize      = 20
+	edgeSize      = 8
+)
+
+// ErrInvalid 文件不是有效的扁平自动机
+var ErrInvalid = errors.New("invalid flat automaton")
+
+// Automaton 只读的扁平 AC 自动机，实现 filter.Filter 与 filter.RangedFilter 接口
+type Automaton struct {
+	mu     sync.RWMutex // 保护 data，Close 后不再访问映射内存
+	data   []byte
+	meta   []byte
+	nodes  []byte
+	edges  []byte
+	n      uint32
+	e      uint32
+	closer func() error
+}
+
+// New 基于字节数据（通常为内存映射的文件内容）创建自动机，校验头部与长度但不复制数据
+// closer 在 Close 时调用（如解除映射），可为 nil
+func New(data []byte, closer func() error) (*Automaton, error) {
+	if len(data) < headerSize || string(data[:4]) != magic {
+		return nil, fmt.Errorf("%w: bad magic", ErrInvalid)
+	}
+	if v := binary.LittleEndian.Uint32(data[4:]); v != formatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalid, v)
+	}
+	n := binary.LittleEndian.Uint32(data[8:])
+	e := binary.LittleEndian.Uint32(data[12:])
+	m := uint64(binary.LittleEndian.Uint32(data[16:]))
+	metaEnd := headerSize + m
+	nodesStart := align4(metaEnd)
+	nodesEnd := nodesStart + uint64(n)*nodeSize
+	if n == 0 || nodesEnd+uint64(e)*edgeSize != uint64(len(data)) {
+		return nil, fmt.Errorf("%w: size mismatch", ErrInvalid)
+	}
+	return &Automaton{
+		data:   data,
+		meta:   data[headerSize:metaEnd],
+		nodes:  data[nodesStart:nodesEnd],
+		edges:  data[nodesEnd:],
+		n:      n,
+		e:      e,
+		closer: closer,
+	}, nil
+}
+
+// Meta 返回写入时附带的元数据（引用映射内存，Close 后不可使用）
+func (a *Automaton) Meta() []byte { return a.meta }
+
+// Verify 校验全部数据的 CRC32（会读取整个文件）
+func (a *Automaton) Verify() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.data == nil {
+		return errors.New("automaton closed")
+	}
+	if crc32.ChecksumIEEE(a.data[headerSize:]) != binary.LittleEndian.Uint32(a.data[20:]) {
+		return fmt.Errorf("%w: checksum mismatch", ErrInvalid)
+	}
+	return nil
+}
+
+// Close 释放底层数据（如解除内存映射），之后的查询不再命中任何词
+func (a *Automaton) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.data == nil {
+		return nil
+	}
+	a.data, a.meta, a.nodes, a.edges, a.n, a.e = nil, nil, nil, nil, 0, 0
+	if a.closer != nil {
+		return a.closer()
+	}
+	return nil
+}
+
+// node 读取节点记录；越界的序号按根节点处理，保证损坏的文件不会导致越界或死循环
+func (a *Automaton) node(i uint32) (edgeStart, edgeCount, fail, length, dict uint32) {
+	rec := a.nodes[uint64(i)*nodeSize:]
+	edgeStart = binary.LittleEndian.Uint32(rec)
+	edgeCount = binary.LittleEndian.Uint32(rec[4:])
+	fail = binary.LittleEndian.Uint32(rec[8:])
+	length = binary.LittleEndian.Uint32(rec[12:])
+	dict = binary.LittleEndian.Uint32(rec[16:])
+	if uint64(edgeStart)+uint64(edgeCount) > uint64(a.e) {
+		edgeCount = 0
+	}
+	if fail >= i {
+		fail = 0
+	}
+	if dict >= i {
+		dict = 0
+	}
+	return
+}
+
+// child 在节点的边中二分查找字符 r
+func (a *Automaton) child(edgeStart, edgeCount uint32, r rune) (uint32, bool) {
+	lo, hi := edgeStart, edgeStart+edgeCount
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		rec := a.edges[uint64(mid)*edgeSize:]
+		c := rune(binary.LittleEndian.Uint32(rec))
+		switch {
+		case c == r:
+			target := binary.LittleEndian.Uint32(rec[4:])
+			if target >= a.n {
+				return 0, false
+			}
+			return target, true
+		case c < r:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false
+}
+
+// scan 扫描文本，对每个命中区间调用 fn（按结束位置排序，同一结束位置时较长的词在前）
+// fn 返回 false 时停止
+func (a *Automaton) scan(runes []rune, fn func(filter.Range) bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.n == 0 {
+		return
+	}
+	var state uint32
+	for i, r := range runes {
+		for {
+			start, count, fail, _, _ := a.node(state)
+			if next, ok := a.child(start, count, r); ok {
+				state = next
+				break
+			}
+			if state == 0 {
+				break
+			}
+			state = fail
+		}
+		for out := state; out != 0; {
+			_, _, _, length, dict := a.node(out)
+			if length > 0 && int(length) <= i+1 {
+				if !fn(filter.Range{Start: i + 1 - int(length), End: i}) {
+					return
+				}
+			}
+			out = dict
+		}
+	}
+}
+
+// FindAllRanges 返回全部命中区间，实现 filter.RangedFilter 接口
+func (a *Automaton) FindAllRanges(text string) []filter.Range {
+	var ranges []filter.Range
+	a.scan([]rune(text), func(r filter.Range) bool {
+		ranges = append(ranges, r)
+		return true
+	})
+	return ranges
+}
+
+func (a *Automaton) FindAll(text string) []string {
+	runes := []rune(text)
+	seen := make(map[string]struct{})
+	res := make([]string, 0)
+	a.scan(runes, func(r filter.Range) bool {
+		word := string(runes[r.Start : r.End+1])
+		if _, ok := seen[word]; !ok {
+			seen[word] = struct{}{}
+			res = append(res, word)
+		}
+		return true
+	})
+	return res
+}
+
+func (a *Automaton) FindAllCount(text string) map[string]int {
+	runes := []rune(text)
+	res := make(map[string]int)
+	a.scan(runes, func(r filter.Range) bool {
+		res[string(runes[r.Start:r.End+1])]++
+		return true
+	})
+	return res
+}
+
+func (a *Automaton) FindOne(text string) string {
+	runes := []rune(text)
+	word := ""
+	a.scan(runes, func(r filter.Range) bool {
+		word = string(runes[r.Start : r.End+1])
+		return false
+	})
+	return word
+}
+
+func (a *Automaton) IsSensitive(text string) bool { return a.FindOne(text) != "" }
+
+func (a *Automaton) Replace(text string, repl rune) string {
+	runes := []rune(text)
+	a.scan([]rune(text), func(r filter.Range) bool {
+		for k := r.Start; k <= r.End; k++ {
+			runes[k] = repl
+		}
+		return true
+	})
+	return string(runes)
+}
+
+func (a *Automaton) Remove(text string) string {
+	runes := []rune(text)
+	del := make([]bool, len(runes))
+	a.scan(runes, func(r filter.Range) bool {
+		for k := r.Start; k <= r.End; k++ {
+			del[k] = true
+		}
+		return true
+	})
+	out := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if !del[i] {
+			out = append(out, r)
+		}
+	}
+	return string(out)
+}
+
+// ==================== 构建 ====================
+
+type buildEdge struct {
+	r      rune
+	target uint32
+}
+
+type buildNode struct {
+	edges  []buildEdge // 按字符升序（按排序后的词插入，追加即有序）
+	length uint32
+}
+
+// Write 使用词表构建扁平自动机并写入 w，meta 为附带的元数据
+// 词按字典序插入，子节点查找只需比较最后一条边，构建时内存占用与节点数成正比
+func Write(w io.Writer, words []string, meta []byte) error {
+	sorted := append([]string{}, words...)
+	sort.Strings(sorted)
+
+	nodes := []buildNode{{}}
+	for i, word := range sorted {
+		if word == "" || (i > 0 && word == sorted[i-1]) {
+			continue
+		}
+		cur := uint32(0)
+		length := uint32(0)
+		for _, r := range word {
+			length++
+			edges := nodes[cur].edges
+			if n := len(edges); n > 0 && edges[n-1].r == r {
+				cur = edges[n-1].target
+				continue
+			}
+			nodes = append(nodes, buildNode{})
+			next := uint32(len(nodes) - 1)
+			nodes[cur].edges = append(nodes[cur].edges, buildEdge{r: r, target: next})
+			cur = next
+		}
+		nodes[cur].length = length
+	}
+
+	// BFS 重新编号，保证失败指针与输出链接指向序号更小的节点
+	order := make([]uint32, 0, len(nodes))
+	index := make([]uint32, len(nodes))
+	order = append(order, 0)
+	edgeCount := 0
+	for i := 0; i < len(order); i++ {
+		for _, e := range nodes[order[i]].edges {
+			index[e.target] = uint32(len(order))
+			order = append(order, e.target)
+			edgeCount++
+		}
+	}
+
+	goTo := func(old uint32, r rune) (uint32, bool) {
+		edges := nodes[old].edges
+		k := sort.Search(len(edges), func(j int) bool { return edges[j].r >= r })
+		if k < len(edges) && edges[k].r == r {
+			return edges[k].target, true
+		}
+		return 0, false
+	}
+	fail := make([]uint32, len(nodes)) // 按原序号
+	dict := make([]uint32, len(nodes))
+	for _, u := range order {
+		for _, e := range nodes[u].edges {
+			c := e.target
+			if u != 0 {
+				f := fail[u]
+				for {
+					if next, ok := goTo(f, e.r); ok {
+						fail[c] = next
+						break
+					}
+					if f == 0 {
+						break
+					}
+					f = fail[f]
+				}
+			}
+			if f := fail[c]; nodes[f].length > 0 {
+				dict[c] = f
+			} else {
+				dict[c] = dict[f]
+			}
+		}
+	}
+
+	metaPad := int(align4(uint64(len(meta)))) - len(meta)
+	body := make([]byte, 0, len(meta)+metaPad+len(order)*nodeSize+edgeCount*edgeSize)
+	body = append(body, meta...)
+	body = append(body, make([]byte, metaPad)...)
+	edgeStart := uint32(0)
+	for _, old := range order {
+		n := nodes[old]
+		body = binary.LittleEndian.AppendUint32(body, edgeStart)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(n.edges)))
+		body = binary.LittleEndian.AppendUint32(body, index[fail[old]])
+		body = binary.LittleEndian.AppendUint32(body, n.length)
+		body = binary.LittleEndian.AppendUint32(body, index[dict[old]])
+		edgeStart += uint32(len(n.edges))
+	}
+	for _, old := range order {
+		for _, e := range nodes[old].edges {
+			body = binary.LittleEndian.AppendUint32(body, uint32(e.r))
+			body = binary.LittleEndian.AppendUint32(body, index[e.target])
+		}
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = binary.LittleEndian.AppendUint32(header, formatVersion)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(order)))
+	header = binary.LittleEndian.AppendUint32(header, uint32(edgeCount))
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(meta)))
+	header = binary.LittleEndian.AppendUint32(header, crc32.ChecksumIEEE(body))
+	header = append(header, make([]byte, headerSize-len(header))...)
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(body)
+	return err
+}
+
+func align4(n uint64) uint64 { return (n + 3) &^ 3 }
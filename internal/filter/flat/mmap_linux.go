@@ -0,0 +1,34 @@
+//go:build linux
+
+package flat
+
+import (
+	"os"
+	"syscall"
+)
+
+// Open 以只读方式内存映射文件并创建自动机，多个进程打开同一文件时共享页缓存
+func Open(path string) (*Automaton, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < headerSize {
+		return New(nil, nil)
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	a, err := New(data, func() error { return syscall.Munmap(data) })
+	if err != nil {
+		_ = syscall.Munmap(data)
+		return nil, err
+	}
+	return a, nil
+}
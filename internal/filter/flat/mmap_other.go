@@ -0,0 +1,14 @@
+//go:build !linux
+
+package flat
+
+import "os"
+
+// Open 读取文件并创建自动机（非 Linux 平台不使用内存映射）
+func Open(path string) (*Automaton, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(data, nil)
+}
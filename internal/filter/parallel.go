@@ -0,0 +1,50 @@
+package filter
+
+import "sync"
+
+// ParallelRanges 将长度为 n 的文本按结束位置划分为最多 workers 块（每块至少 minChunk 个字符）并发匹配
+// 每块从 emitFrom-overlap 开始扫描、只输出结束位置落在 [emitFrom, to) 的区间，
+// overlap 取最长词长 - 1，保证跨块的词不会遗漏；各块结果按顺序拼接，与串行匹配的结果与顺序完全一致
+// scan(from, emitFrom, to) 从 from 处的初始状态开始扫描到 to，返回结束位置不小于 emitFrom 的区间
+func ParallelRanges(n, overlap, workers, minChunk int, scan func(from, emitFrom, to int) []Range) []Range {
+	if minChunk < 1 {
+		minChunk = 1
+	}
+	chunks := n / minChunk
+	if chunks > workers {
+		chunks = workers
+	}
+	if chunks <= 1 {
+		return scan(0, 0, n)
+	}
+	size := (n + chunks - 1) / chunks
+	results := make([][]Range, chunks)
+	var wg sync.WaitGroup
+	for k := 0; k < chunks; k++ {
+		emitFrom := k * size
+		to := emitFrom + size
+		if to > n {
+			to = n
+		}
+		from := emitFrom - overlap
+		if from < 0 {
+			from = 0
+		}
+		wg.Add(1)
+		go func(k, from, emitFrom, to int) {
+			defer wg.Done()
+			results[k] = scan(from, emitFrom, to)
+		}(k, from, emitFrom, to)
+	}
+	wg.Wait()
+
+	total := 0
+	for _, r := range results {
+		total += len(r)
+	}
+	ranges := make([]Range, 0, total)
+	for _, r := range results {
+		ranges = append(ranges, r...)
+	}
+	return ranges
+}
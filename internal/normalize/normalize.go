@@ -1,6 +1,7 @@
 package normalize
 
 import (
+	"strings"
 	"unicode"
 
 	"github.com/LuYongwang/go-sensitive-word/internal/jianfan"
@@ -16,6 +17,56 @@ type Config struct {
 	IgnoreEnglishStyle bool
 	RemoveZeroWidth    bool
 	HomoglyphMap       map[rune]rune
+	Skip               uint8  // 跳过的干扰字符类别（SkipPunct 等的组合）
+	SkipRunes          string // 额外跳过的字符
+}
+
+// 干扰字符类别
+const (
+	SkipPunct  uint8 = 1 << iota // 标点
+	SkipSymbol                   // 符号（数学、货币、修饰符号等）
+	SkipSpace                    // 空白
+	SkipEmoji                    // emoji（含变体选择符、零宽连接符与肤色修饰符）
+)
+
+// Skippable 判断字符是否为配置中可跳过的干扰字符
+func Skippable(r rune, cfg Config) bool {
+	if cfg.Skip == 0 && cfg.SkipRunes == "" {
+		return false
+	}
+	switch {
+	case cfg.SkipRunes != "" && strings.ContainsRune(cfg.SkipRunes, r):
+		return true
+	case cfg.Skip&SkipSpace != 0 && unicode.IsSpace(r):
+		return true
+	case cfg.Skip&SkipPunct != 0 && unicode.IsPunct(r):
+		return true
+	case cfg.Skip&SkipEmoji != 0 && isEmoji(r):
+		return true
+	case cfg.Skip&SkipSymbol != 0 && unicode.IsSymbol(r):
+		return true
+	}
+	return false
+}
+
+// isEmoji 判断是否为 emoji 及其组合字符
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // 表情、图形符号、交通、补充符号等
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // 杂项符号与装饰符号
+		return true
+	case r == 0xFE0F || r == 0x200D || r == 0x20E3: // 变体选择符、零宽连接符、组合用键帽
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // 旗帜标签
+		return true
+	}
+	return false
+}
+
+// Ignorable 判断字符是否在归一化时被当作干扰剔除（可跳过的干扰字符或开启剔除时的零宽字符）
+func Ignorable(r rune, cfg Config) bool {
+	return Skippable(r, cfg) || cfg.RemoveZeroWidth && zeroWidthChars[r]
 }
 
 // 数字映射表：各种数字写法 -> 阿拉伯数字
@@ -131,6 +182,11 @@ func normalizeRune(r rune, cfg Config) rune {
 		}
 	}
 
+	// 干扰字符跳过（标点、符号、空白、emoji）
+	if Skippable(r, cfg) {
+		return rune(-1)
+	}
+
 	// 2. 同形字映射
 	if cfg.HomoglyphMap != nil {
 		if mapped, ok := cfg.HomoglyphMap[r]; ok {
@@ -175,6 +231,37 @@ func normalizeRune(r rune, cfg Config) rune {
 	return r
 }
 
+// Stream 逐字符归一化，规则与 NormalizeTextWithMap 一致（含零宽剔除与重复压缩）
+// 用于边读取边匹配的场景，无需构造完整的规范化文本
+type Stream struct {
+	cfg     Config
+	last    rune
+	hasLast bool
+}
+
+// NewStream 创建逐字符归一化器
+func NewStream(cfg Config) Stream {
+	return Stream{cfg: cfg}
+}
+
+// Next 归一化一个字符，返回 false 表示该字符被剔除（零宽字符、干扰字符或连续重复）
+func (s *Stream) Next(r rune) (rune, bool) {
+	nr := normalizeRune(r, s.cfg)
+	// 跳过零宽字符（标记为 -1）
+	if nr == rune(-1) {
+		return 0, false
+	}
+	if s.cfg.IgnoreRepeat {
+		if s.hasLast && nr == s.last {
+			// 跳过连续重复
+			return 0, false
+		}
+		s.last = nr
+		s.hasLast = true
+	}
+	return nr, true
+}
+
 // NormalizeTextWithMap 对文本做归一化，同时返回从规范化索引到原始索引的映射
 // 返回：规范化后的字符串、规范化索引 -> 原始索引 的映射
 func NormalizeTextWithMap(s string, cfg Config) (string, []int) {
@@ -182,24 +269,12 @@ func NormalizeTextWithMap(s string, cfg Config) (string, []int) {
 	norm := make([]rune, 0, len(runes))
 	idxMap := make([]int, 0, len(runes))
 
-	var last rune
-	var hasLast bool
+	stream := NewStream(cfg)
 	for i, r := range runes {
-		nr := normalizeRune(r, cfg)
-		// 跳过零宽字符（标记为 -1）
-		if nr == rune(-1) {
-			continue
-		}
-		if cfg.IgnoreRepeat {
-			if hasLast && nr == last {
-				// 跳过连续重复
-				continue
-			}
-			last = nr
-			hasLast = true
+		if nr, ok := stream.Next(r); ok {
+			norm = append(norm, nr)
+			idxMap = append(idxMap, i)
 		}
-		norm = append(norm, nr)
-		idxMap = append(idxMap, i)
 	}
 
 	return string(norm), idxMap
@@ -0,0 +1,96 @@
+package store
+
+import "sort"
+
+// AddAlias 将多个变体词绑定到同一个规范词，组成别名组
+// 不存在的词会一并添加；变体原属于其他别名组时会移动到新组，
+// 规范词本身是其他组的变体时，绑定到该组的规范词
+func (m *MemoryModel) AddAlias(canonical string, variants ...string) error {
+	entries := make([]Entry, 0, len(variants)+1)
+	entries = append(entries, Entry{Word: canonical})
+	for _, variant := range variants {
+		entries = append(entries, Entry{Word: variant, Canonical: canonical})
+	}
+	return m.AddEntries(entries)
+}
+
+// GetCanonical 获取词所属别名组的规范词
+// 词不属于任何别名组时返回词本身，词不存在时返回空字符串
+func (m *MemoryModel) GetCanonical(word string) string {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	if canonical, ok := m.canonical[word]; ok {
+		return canonical
+	}
+	if m.storeExists(word) {
+		return word
+	}
+	return ""
+}
+
+// GetAliases 获取词所属别名组的全部变体（不含规范词，按字典序）
+func (m *MemoryModel) GetAliases(word string) []string {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	if canonical, ok := m.canonical[word]; ok {
+		word = canonical
+	}
+	variants := append([]string{}, m.aliases[word]...)
+	sort.Strings(variants)
+	return variants
+}
+
+// bindAliasLocked 将变体绑定到规范词，调用方需持有 storeMu 写锁
+func (m *MemoryModel) bindAliasLocked(variant, canonical string) {
+	if c, ok := m.canonical[canonical]; ok {
+		canonical = c
+	}
+	if variant == canonical {
+		return
+	}
+	m.unbindAliasLocked(variant)
+	// 变体原本是其他组的规范词时，整组并入新组
+	for _, v := range m.aliases[variant] {
+		m.canonical[v] = canonical
+		m.aliases[canonical] = append(m.aliases[canonical], v)
+	}
+	delete(m.aliases, variant)
+	m.canonical[variant] = canonical
+	m.aliases[canonical] = append(m.aliases[canonical], variant)
+}
+
+// unbindAliasLocked 将变体移出所属别名组，调用方需持有 storeMu 写锁
+func (m *MemoryModel) unbindAliasLocked(variant string) {
+	canonical, ok := m.canonical[variant]
+	if !ok {
+		return
+	}
+	delete(m.canonical, variant)
+	list := m.aliases[canonical]
+	for i, v := range list {
+		if v == variant {
+			list = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(list) == 0 {
+		delete(m.aliases, canonical)
+	} else {
+		m.aliases[canonical] = list
+	}
+}
+
+// expandAliasGroups 将规范词展开为整个别名组（删除规范词时整组删除）
+func (m *MemoryModel) expandAliasGroups(words []string) []string {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	if len(m.aliases) == 0 {
+		return words
+	}
+	expanded := make([]string, 0, len(words))
+	for _, word := range words {
+		expanded = append(expanded, word)
+		expanded = append(expanded, m.aliases[word]...)
+	}
+	return expanded
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"sort"
+	"strings"
+)
+
+// 新增词缓冲的上限，超出后归并到有序词表
+const indexMergeThreshold = 1024
+
+// wordIndex 词库的有序索引与词的加入顺序（由 storeMu 保护）
+// 按前缀检索时只遍历前缀所在的区间；加入顺序用于最左优先匹配模式在同一起点的取舍
+// 新增的词先进入无序缓冲，积累到上限后排序归并；删除只记数，已删除的词超过一半时压缩，
+// 逐词写入与批量写入的均摊代价都不随词库大小线性增长
+// 不变式：sorted 与 added 不相交，added 中的词都在词库中，stale 为 sorted 中已删除的词数
+type wordIndex struct {
+	sorted []string // 有序词表，可能包含已删除的词
+	added  []string // 尚未归并的新增词（无序）
+	stale  int      // sorted 中已删除的词数
+
+	seq  map[string]uint64 // 词加入词库的序号
+	next uint64            // 下一个序号
+}
+
+// reset 以完整词表重建索引
+func (x *wordIndex) reset(words []string) {
+	x.sorted = append(make([]string, 0, len(words)), words...)
+	sort.Strings(x.sorted)
+	x.added = nil
+	x.stale = 0
+	x.seq = make(map[string]uint64, len(words))
+	for _, w := range words {
+		x.assign(w)
+	}
+}
+
+// assign 为新加入的词分配序号
+func (x *wordIndex) assign(word string) {
+	if x.seq == nil {
+		x.seq = make(map[string]uint64)
+	}
+	x.seq[word] = x.next
+	x.next++
+}
+
+// add 记录新加入词库的词
+func (x *wordIndex) add(word string) {
+	x.assign(word)
+	// 曾被删除但仍留在有序词表中的词直接复用
+	if i := sort.SearchStrings(x.sorted, word); i < len(x.sorted) && x.sorted[i] == word {
+		x.stale--
+		return
+	}
+	x.added = append(x.added, word)
+	if len(x.added) >= indexMergeThreshold {
+		x.merge(nil)
+	}
+}
+
+// del 记录从词库删除的词，exists 判断词是否仍在词库中（压缩时使用）
+func (x *wordIndex) del(word string, exists func(string) bool) {
+	delete(x.seq, word)
+	for i, w := range x.added {
+		if w == word {
+			last := len(x.added) - 1
+			x.added[i] = x.added[last]
+			x.added = x.added[:last]
+			return
+		}
+	}
+	if x.stale++; x.stale > len(x.sorted)/2 {
+		x.merge(exists)
+	}
+}
+
+// merge 将新增词排序归并到有序词表；exists 不为 nil 时同时剔除已删除的词
+func (x *wordIndex) merge(exists func(string) bool) {
+	sort.Strings(x.added)
+	merged := make([]string, 0, len(x.sorted)+len(x.added))
+	i, j := 0, 0
+	for i < len(x.sorted) || j < len(x.added) {
+		var w string
+		if j == len(x.added) || (i < len(x.sorted) && x.sorted[i] < x.added[j]) {
+			w = x.sorted[i]
+			i++
+			if exists != nil && !exists(w) {
+				continue
+			}
+		} else {
+			w = x.added[j]
+			j++
+		}
+		merged = append(merged, w)
+	}
+	x.sorted = merged
+	x.added = x.added[:0]
+	if exists != nil {
+		x.stale = 0
+	}
+}
+
+// prefix 按升序返回以 prefix 开头且仍在词库中的词
+func (x *wordIndex) prefix(prefix string, exists func(string) bool) []string {
+	var words []string
+	for i := sort.SearchStrings(x.sorted, prefix); i < len(x.sorted) && strings.HasPrefix(x.sorted[i], prefix); i++ {
+		if exists(x.sorted[i]) {
+			words = append(words, x.sorted[i])
+		}
+	}
+	n := len(words)
+	for _, w := range x.added {
+		if strings.HasPrefix(w, prefix) {
+			words = append(words, w)
+		}
+	}
+	if len(words) > n {
+		sort.Strings(words)
+	}
+	return words
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// LimitKind 资源限制类型
+type LimitKind string
+
+const (
+	LimitInputRunes LimitKind = "input_runes" // 单次检测的输入字符数
+	LimitMatches    LimitKind = "matches"     // 单次调用的命中数
+	LimitWordRunes  LimitKind = "word_runes"  // 词的字符数
+	LimitDictWords  LimitKind = "dict_words"  // 词库词数
+)
+
+// LimitError 超出资源限制
+type LimitError struct {
+	Kind   LimitKind // 限制类型
+	Max    int       // 限制值
+	Actual int       // 实际值（命中数达到上限后提前停止时为上限 + 1）
+	Word   string    // 超长的词（仅 LimitWordRunes）
+}
+
+func (e *LimitError) Error() string {
+	if e.Word != "" {
+		return fmt.Sprintf("%s limit exceeded: %q has %d runes, max %d", e.Kind, e.Word, e.Actual, e.Max)
+	}
+	return fmt.Sprintf("%s limit exceeded: %d > %d", e.Kind, e.Actual, e.Max)
+}
+
+// SetDictLimits 设置词的最大字符数与词库最大词数，<=0 表示不限制
+// 超长词：添加时整批拒绝并返回 *LimitError，加载词库时记为 RejectTooLong；
+// 词库已满：写入后的词数超出上限时整批拒绝并返回 *LimitError，已存在的词不受影响
+func (m *MemoryModel) SetDictLimits(maxWordRunes, maxWords int) {
+	m.maxWordRunes.Store(int64(maxWordRunes))
+	m.maxWords.Store(int64(maxWords))
+}
+
+// checkWordLimit 检查词的长度，超长时返回 *LimitError
+func (m *MemoryModel) checkWordLimit(word string) error {
+	max := int(m.maxWordRunes.Load())
+	if max <= 0 {
+		return nil
+	}
+	if n := utf8.RuneCountInString(word); n > max {
+		return &LimitError{Kind: LimitWordRunes, Max: max, Actual: n, Word: word}
+	}
+	return nil
+}
+
+// checkDictLimit 检查词库是否还能再添加 n 个新词，调用方需持有 storeMu 写锁
+func (m *MemoryModel) checkDictLimit(n int) error {
+	max := int(m.maxWords.Load())
+	if max <= 0 {
+		return nil
+	}
+	if total := int(m.totalWords.Load()) + n; total > max {
+		return &LimitError{Kind: LimitDictWords, Max: max, Actual: total}
+	}
+	return nil
+}
+
+// checkNewWordsLocked 检查写入 words 后词库是否超出上限（只统计尚不存在的不同词），调用方需持有 storeMu 写锁
+func (m *MemoryModel) checkNewWordsLocked(words []string) error {
+	if m.maxWords.Load() <= 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		word = strings.TrimSpace(word)
+		if word == "" || m.storeExists(word) {
+			continue
+		}
+		seen[word] = struct{}{}
+	}
+	return m.checkDictLimit(len(seen))
+}
+
+// withLimits 将词长限制合并到加载选项（取较小值）
+func (m *MemoryModel) withLimits(opts LoadOptions) LoadOptions {
+	if max := int(m.maxWordRunes.Load()); max > 0 && (opts.MaxWordLength <= 0 || opts.MaxWordLength > max) {
+		opts.MaxWordLength = max
+	}
+	return opts
+}
@@ -0,0 +1,278 @@
+package store
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RejectReason 词条校验未通过的原因
+type RejectReason string
+
+const (
+	RejectEmpty       RejectReason = "empty"        // 归一化后为空
+	RejectTooLong     RejectReason = "too_long"     // 超过最大长度
+	RejectControlChar RejectReason = "control_char" // 包含控制字符
+)
+
+// RejectedLine 校验未通过的行
+type RejectedLine struct {
+	Line   int          // 行号（从 1 开始）
+	Text   string       // 原始内容（已去除首尾空白）
+	Reason RejectReason // 未通过原因
+}
+
+// LoadReport 一次词库加载的报告
+type LoadReport struct {
+	Source     string         // 来源（如 file://path、embed、reader、callback://xxx）
+	Lines      int            // 读取的行数（含空行）
+	Blank      int            // 空行数
+	NewWords   int            // 新增的词数
+	Duplicates int            // 重复的词数（词库中已存在或本次重复出现）
+	Rejected   []RejectedLine // 校验未通过的行
+}
+
+// LoadOptions 词库加载选项
+type LoadOptions struct {
+	Normalize     func(string) string // 词的归一化函数，为 nil 时转小写（与 LoadDict 一致）
+	MaxWordLength int                 // 词的最大长度（按归一化后的 rune 计），<=0 表示不限制
+	Atomic        bool                // 多个来源时全部读取并校验成功后才写入词库
+	Source        string              // 为加载的词记录来源标识（为空则不记录）
+}
+
+// loadItem 通过校验的词及其行号
+type loadItem struct {
+	line int
+	word string // 归一化后的词
+	raw  string // 原始写法
+}
+
+// parsedDict 读取并校验后的词库内容
+type parsedDict struct {
+	report LoadReport
+	items  []loadItem
+}
+
+// parseDict 逐行读取并校验词库，不修改词库
+func parseDict(reader io.Reader, source string, opts LoadOptions) (*parsedDict, error) {
+	normalize := opts.Normalize
+	if normalize == nil {
+		normalize = strings.ToLower
+	}
+	parsed := &parsedDict{report: LoadReport{Source: source}}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		parsed.report.Lines++
+		lineNo := parsed.report.Lines
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			parsed.report.Blank++
+			continue
+		}
+		if reason, ok := validateWord(raw, normalize, opts.MaxWordLength); !ok {
+			parsed.report.Rejected = append(parsed.report.Rejected, RejectedLine{Line: lineNo, Text: raw, Reason: reason})
+			continue
+		}
+		parsed.items = append(parsed.items, loadItem{line: lineNo, word: normalize(raw), raw: raw})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", source, err)
+	}
+	return parsed, nil
+}
+
+// parseWords 校验词列表（第 N 个词记为第 N 行），不修改词库
+func parseWords(words []string, source string, opts LoadOptions) *parsedDict {
+	normalize := opts.Normalize
+	if normalize == nil {
+		normalize = strings.ToLower
+	}
+	parsed := &parsedDict{report: LoadReport{Source: source, Lines: len(words)}}
+	for i, word := range words {
+		raw := strings.TrimSpace(word)
+		if raw == "" {
+			parsed.report.Blank++
+			continue
+		}
+		if reason, ok := validateWord(raw, normalize, opts.MaxWordLength); !ok {
+			parsed.report.Rejected = append(parsed.report.Rejected, RejectedLine{Line: i + 1, Text: raw, Reason: reason})
+			continue
+		}
+		parsed.items = append(parsed.items, loadItem{line: i + 1, word: normalize(raw), raw: raw})
+	}
+	return parsed
+}
+
+// validateWord 按规则校验词条
+func validateWord(raw string, normalize func(string) string, maxLen int) (RejectReason, bool) {
+	for _, r := range raw {
+		if unicode.IsControl(r) {
+			return RejectControlChar, false
+		}
+	}
+	word := normalize(raw)
+	if strings.TrimSpace(word) == "" {
+		return RejectEmpty, false
+	}
+	if maxLen > 0 && utf8.RuneCountInString(word) > maxLen {
+		return RejectTooLong, false
+	}
+	return "", true
+}
+
+// apply 将一个或多个校验通过的词库一次性写入词库并补全报告中的新增/重复计数
+// 写入后的词数超出上限时整体拒绝并返回 *LimitError，不写入任何词
+func (m *MemoryModel) apply(source string, dicts ...*parsedDict) error {
+	var words []string
+	for _, parsed := range dicts {
+		for _, item := range parsed.items {
+			words = append(words, item.word)
+		}
+	}
+	newWords := make([]string, 0, len(words))
+	m.storeMu.Lock()
+	if err := m.checkNewWordsLocked(words); err != nil {
+		m.storeMu.Unlock()
+		return err
+	}
+	for _, parsed := range dicts {
+		for _, item := range parsed.items {
+			if m.storeExists(item.word) {
+				parsed.report.Duplicates++
+			} else {
+				m.store[item.word] = struct{}{}
+				m.index.add(item.word)
+				m.totalWords.Add(1)
+				parsed.report.NewWords++
+				newWords = append(newWords, item.word)
+			}
+			m.addSourceLocked(item.word, source)
+			m.addOriginalLocked(item.word, item.raw, source)
+		}
+	}
+	m.recordUpdateLocked(len(newWords))
+	defer m.storeMu.Unlock()
+	return m.notifyLocked(m.addChan, newWords)
+}
+
+// namedReader 带来源名称的读取器
+type namedReader struct {
+	source string
+	open   func() (io.ReadCloser, error)
+}
+
+// load 读取、校验并写入多个来源，返回每个来源的报告
+// opts.Atomic 为 true 时，所有来源读取并校验成功后才统一写入
+func (m *MemoryModel) load(readers []namedReader, opts LoadOptions) ([]LoadReport, error) {
+	reports := make([]LoadReport, 0, len(readers))
+	parsedAll := make([]*parsedDict, 0, len(readers))
+	defer func() { m.setLastLoadReports(reports) }()
+
+	opts = m.withLimits(opts)
+	for _, nr := range readers {
+		parsed, err := m.parseNamed(nr, opts)
+		if err != nil {
+			return reports, err
+		}
+		if opts.Atomic {
+			parsedAll = append(parsedAll, parsed)
+			continue
+		}
+		if err := m.apply(opts.Source, parsed); err != nil {
+			return reports, err
+		}
+		reports = append(reports, parsed.report)
+		m.recordSource(nr.source)
+	}
+	if len(parsedAll) == 0 {
+		return reports, nil
+	}
+
+	// 原子加载：全部来源一次性写入，超出词库上限时整体拒绝
+	if err := m.apply(opts.Source, parsedAll...); err != nil {
+		return reports, err
+	}
+	for i, parsed := range parsedAll {
+		reports = append(reports, parsed.report)
+		m.recordSource(readers[i].source)
+	}
+	return reports, nil
+}
+
+func (m *MemoryModel) parseNamed(nr namedReader, opts LoadOptions) (*parsedDict, error) {
+	rc, err := nr.open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+	return parseDict(rc, nr.source, opts)
+}
+
+// recordSource 记录文件、回调等加载来源（embed 与 reader 不记录）
+func (m *MemoryModel) recordSource(source string) {
+	if !strings.HasPrefix(source, "file://") && !strings.HasPrefix(source, "callback://") {
+		return
+	}
+	m.mu.Lock()
+	m.sources = append(m.sources, source)
+	m.stats.Source = append(m.stats.Source, source)
+	m.mu.Unlock()
+}
+
+func (m *MemoryModel) setLastLoadReports(reports []LoadReport) {
+	m.mu.Lock()
+	m.lastReports = append([]LoadReport{}, reports...)
+	m.mu.Unlock()
+}
+
+// LastLoadReports 返回最近一次加载调用的报告（每个来源一份）
+func (m *MemoryModel) LastLoadReports() []LoadReport {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]LoadReport{}, m.lastReports...)
+}
+
+// LoadDictWithReport 从 Reader 加载词库并返回加载报告
+func (m *MemoryModel) LoadDictWithReport(reader io.Reader, opts LoadOptions) (*LoadReport, error) {
+	reports, err := m.load([]namedReader{readerSource("reader", reader)}, opts)
+	if len(reports) == 0 {
+		return nil, err
+	}
+	return &reports[0], err
+}
+
+// LoadDictPathWithReport 从多个文件加载词库并返回每个文件的加载报告
+// opts.Atomic 为 true 时，任一文件读取失败都不会写入任何词
+func (m *MemoryModel) LoadDictPathWithReport(opts LoadOptions, paths ...string) ([]LoadReport, error) {
+	readers := make([]namedReader, 0, len(paths))
+	for _, path := range paths {
+		path := path
+		readers = append(readers, namedReader{
+			source: "file://" + path,
+			open:   func() (io.ReadCloser, error) { return os.Open(path) },
+		})
+	}
+	return m.load(readers, opts)
+}
+
+// LoadDictEmbedWithReport 加载内置词库内容并返回每份内容的加载报告
+func (m *MemoryModel) LoadDictEmbedWithReport(opts LoadOptions, contents ...string) ([]LoadReport, error) {
+	readers := make([]namedReader, 0, len(contents))
+	for _, content := range contents {
+		readers = append(readers, readerSource("embed", strings.NewReader(content)))
+	}
+	return m.load(readers, opts)
+}
+
+// readerSource 将 io.Reader 包装为 namedReader
+func readerSource(source string, reader io.Reader) namedReader {
+	return namedReader{
+		source: source,
+		open:   func() (io.ReadCloser, error) { return io.NopCloser(reader), nil },
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"context"
 	"errors"
 	"io"
-	"os"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -13,22 +13,39 @@ import (
 )
 
 type MemoryModel struct {
-	store         map[string]struct{}      // 词库
-	wordSources   map[string][]string      // 词到来源的映射
-	storeMu       sync.RWMutex             // 保护词库 map 和 wordSources
-	totalWords    atomic.Int64             // 原子计数，避免 O(n) 的 Count()
-	addChan       chan string
-	delChan       chan string
-	closed        chan struct{}
-	mu            sync.RWMutex // 保护统计信息
-	stats         Stats
-	sources       []string // 记录加载来源
+	store       map[string]struct{}          // 词库
+	wordSources map[string][]string          // 词到来源的映射
+	wordMeta    map[string]map[string]string // 词到元数据的映射
+	metaKeys    map[string]int               // 元数据键到使用该键的词条数的映射
+	originals   map[string][]Original        // 词到原始写法的映射（首个为规范写法）
+	canonical   map[string]string            // 别名组变体到规范词的映射
+	aliases     map[string][]string          // 别名组规范词到变体列表的映射
+	index       wordIndex                    // 词的有序索引与加入顺序
+	storeMu     sync.RWMutex                 // 保护词库 map 及以上词条附加信息
+	totalWords  atomic.Int64                 // 原子计数，避免 O(n) 的 Count()
+	addChan     chan string
+	delChan     chan string
+	closed      chan struct{}
+	mu          sync.RWMutex // 保护统计信息
+	stats       Stats
+	sources     []string     // 记录加载来源
+	lastReports []LoadReport // 最近一次加载的报告
+
+	metaKeySet atomic.Pointer[map[string]struct{}] // 至少一个词条使用的元数据键（只读快照，供匹配时无锁查询）
+
+	maxWordRunes atomic.Int64 // 词的最大字符数（<=0 表示不限制）
+	maxWords     atomic.Int64 // 词库最大词数（<=0 表示不限制）
 }
 
 func NewMemoryModel() *MemoryModel {
 	return &MemoryModel{
 		store:       make(map[string]struct{}),
 		wordSources: make(map[string][]string),
+		wordMeta:    make(map[string]map[string]string),
+		metaKeys:    make(map[string]int),
+		originals:   make(map[string][]Original),
+		canonical:   make(map[string]string),
+		aliases:     make(map[string][]string),
 		addChan:     make(chan string, 8192),
 		delChan:     make(chan string, 8192),
 		closed:      make(chan struct{}),
@@ -42,81 +59,22 @@ func NewMemoryModel() *MemoryModel {
 	}
 }
 
+// LoadDictPath 从多个文件加载词库，加载报告可通过 LastLoadReports 获取
 func (m *MemoryModel) LoadDictPath(paths ...string) error {
-	for _, path := range paths {
-		err := func(path string) error {
-			f, err := os.Open(path)
-			if err != nil {
-				return err
-			}
-			defer func() { _ = f.Close() }()
-			err = m.LoadDict(f)
-			if err == nil {
-				m.mu.Lock()
-				m.sources = append(m.sources, "file://"+path)
-				m.stats.Source = append(m.stats.Source, "file://"+path)
-				m.mu.Unlock()
-			}
-			return err
-		}(path)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	_, err := m.LoadDictPathWithReport(LoadOptions{}, paths...)
+	return err
 }
 
+// LoadDictEmbed 加载内置词库内容，加载报告可通过 LastLoadReports 获取
 func (m *MemoryModel) LoadDictEmbed(contents ...string) error {
-	for _, con := range contents {
-		reader := strings.NewReader(con)
-		if err := m.LoadDict(reader); err != nil {
-			return err
-		}
-	}
-	return nil
+	_, err := m.LoadDictEmbedWithReport(LoadOptions{}, contents...)
+	return err
 }
 
+// LoadDict 从 Reader 加载词库（每行一个词，转小写），加载报告可通过 LastLoadReports 获取
 func (m *MemoryModel) LoadDict(reader io.Reader) error {
-	buf := bufio.NewReader(reader)
-	count := 0
-	for {
-		line, _, err := buf.ReadLine()
-		if err != nil {
-			if err != io.EOF {
-				return err
-			}
-			break
-		}
-		word := strings.TrimSpace(string(line))
-		if word == "" {
-			continue
-		}
-		word = strings.ToLower(word)
-		m.storeMu.Lock()
-		isNew := true
-		if _, exists := m.store[word]; !exists {
-			m.store[word] = struct{}{}
-			m.totalWords.Add(1)
-		} else {
-			isNew = false
-		}
-		m.storeMu.Unlock()
-		if isNew {
-			count++
-		}
-		select {
-		case m.addChan <- word:
-		case <-m.closed:
-			return errors.New("store closed during load")
-		}
-	}
-	// 更新统计信息
-	m.mu.Lock()
-	m.stats.TotalWords = int(m.totalWords.Load())
-	m.stats.LastUpdate = time.Now()
-	m.stats.UpdateCount += count
-	m.mu.Unlock()
-	return nil
+	_, err := m.LoadDictWithReport(reader, LoadOptions{})
+	return err
 }
 
 func (m *MemoryModel) ReadChan() <-chan string {
@@ -165,14 +123,21 @@ func (m *MemoryModel) LoadDictCallback(loader DictLoader, source string) error {
 	if err != nil {
 		return err
 	}
-	err = m.AddWords(words)
-	if err == nil && source != "" {
-		m.mu.Lock()
-		m.sources = append(m.sources, "callback://"+source)
-		m.stats.Source = append(m.stats.Source, "callback://"+source)
-		m.mu.Unlock()
+	// 回调返回的词视为已归一化，仅去除首尾空白；第 N 个词记为第 N 行
+	opts := LoadOptions{Normalize: strings.TrimSpace}
+	name := "callback"
+	if source != "" {
+		name = "callback://" + source
 	}
-	return err
+	var reports []LoadReport
+	defer func() { m.setLastLoadReports(reports) }()
+	parsed := parseWords(words, name, m.withLimits(opts))
+	if err := m.apply("", parsed); err != nil {
+		return err
+	}
+	reports = append(reports, parsed.report)
+	m.recordSource(name)
+	return nil
 }
 
 func (m *MemoryModel) AddWord(words ...string) error {
@@ -180,45 +145,46 @@ func (m *MemoryModel) AddWord(words ...string) error {
 }
 
 func (m *MemoryModel) AddWords(words []string) error {
+	for _, word := range words {
+		if err := m.checkWordLimit(strings.TrimSpace(word)); err != nil {
+			return err
+		}
+	}
+	// 注意：词应该已经在 Manager 层做了归一化，这里只做 TrimSpace
+	added := make([]string, 0, len(words))
 	count := 0
+	m.storeMu.Lock()
+	if err := m.checkNewWordsLocked(words); err != nil {
+		m.storeMu.Unlock()
+		return err
+	}
 	for _, word := range words {
 		word = strings.TrimSpace(word)
 		if word == "" {
 			continue
 		}
-		// 注意：词应该已经在 Manager 层做了归一化，这里只做 TrimSpace
 		// 只有新词才计数
-		m.storeMu.Lock()
-		if _, exists := m.store[word]; !exists {
+		if !m.storeExists(word) {
 			m.store[word] = struct{}{}
+			m.index.add(word)
 			m.totalWords.Add(1)
 			count++
-		} else {
-			m.store[word] = struct{}{} // 确保存在
-		}
-		m.storeMu.Unlock()
-		select {
-		case m.addChan <- word:
-		case <-m.closed:
-			return errors.New("store closed")
 		}
+		added = append(added, word)
 	}
-	// 更新统计信息
-	m.mu.Lock()
-	m.stats.TotalWords = int(m.totalWords.Load())
-	m.stats.LastUpdate = time.Now()
-	m.stats.UpdateCount += count
-	m.mu.Unlock()
-	return nil
+	m.recordUpdateLocked(count)
+	defer m.storeMu.Unlock()
+	return m.notifyLocked(m.addChan, added)
 }
 
 func (m *MemoryModel) DelWord(words ...string) error {
 	return m.DelWords(words)
 }
 
+// DelWords 批量删除，删除别名组的规范词时整组删除
 func (m *MemoryModel) DelWords(words []string) error {
 	count := 0
-	for _, word := range words {
+	for _, word := range m.expandAliasGroups(words) {
 		word = strings.TrimSpace(word)
 		if word == "" {
 			continue
@@ -227,22 +193,21 @@ func (m *MemoryModel) DelWords(words []string) error {
 		m.storeMu.Lock()
 		if _, exists := m.store[word]; exists {
 			delete(m.store, word)
+			m.index.del(word, m.storeExists)
+			m.clearEntryLocked(word)
+			delete(m.aliases, word)
 			m.totalWords.Add(-1)
 			count++
 		}
+		err := m.notifyLocked(m.delChan, []string{word})
 		m.storeMu.Unlock()
-		select {
-		case m.delChan <- word:
-		case <-m.closed:
-			return errors.New("store closed")
+		if err != nil {
+			return err
 		}
 	}
-	// 更新统计信息
-	m.mu.Lock()
-	m.stats.TotalWords = int(m.totalWords.Load())
-	m.stats.LastUpdate = time.Now()
-	m.stats.UpdateCount += count
-	m.mu.Unlock()
+	m.storeMu.Lock()
+	m.recordUpdateLocked(count)
+	m.storeMu.Unlock()
 	return nil
 }
 
@@ -261,13 +226,7 @@ func (m *MemoryModel) ExportToWriter(w io.Writer) error {
 		_ = writer.Flush() // Flush 的错误在这里无法返回，记录但不中断流程
 	}()
 
-	m.storeMu.RLock()
-	words := make([]string, 0, len(m.store))
-	for word := range m.store {
-		words = append(words, word)
-	}
-	m.storeMu.RUnlock()
-
+	words := m.sortedWords()
 	for _, word := range words {
 		if _, err := writer.WriteString(word + "\n"); err != nil {
 			return err
@@ -278,13 +237,7 @@ func (m *MemoryModel) ExportToWriter(w io.Writer) error {
 
 // ExportToString 导出词库为字符串（每行一个词）
 func (m *MemoryModel) ExportToString() (string, error) {
-	m.storeMu.RLock()
-	words := make([]string, 0, len(m.store))
-	for word := range m.store {
-		words = append(words, word)
-	}
-	m.storeMu.RUnlock()
-
+	words := m.sortedWords()
 	var builder strings.Builder
 	for _, word := range words {
 		builder.WriteString(word)
@@ -303,9 +256,20 @@ func (m *MemoryModel) GetStats() Stats {
 		LastUpdate:  m.stats.LastUpdate,
 		UpdateCount: m.stats.UpdateCount,
 		Source:      append([]string{}, m.stats.Source...),
+		Version:     m.stats.Version,
 	}
 }
 
+// recordUpdateLocked 更新统计信息并递增版本号，调用方需持有 storeMu 写锁（版本号与词条同时可见）
+func (m *MemoryModel) recordUpdateLocked(count int) {
+	m.mu.Lock()
+	m.stats.TotalWords = int(m.totalWords.Load())
+	m.stats.LastUpdate = time.Now()
+	m.stats.UpdateCount += count
+	m.stats.Version++
+	m.mu.Unlock()
+}
+
 // Clear 清空词库
 func (m *MemoryModel) Clear() error {
 	// 先获取所有词，然后删除
@@ -317,6 +281,11 @@ func (m *MemoryModel) Clear() error {
 	m.storeMu.Lock()
 	m.totalWords.Store(0)
 	m.wordSources = make(map[string][]string)
+	m.wordMeta = make(map[string]map[string]string)
+	m.resetMetaKeysLocked()
+	m.originals = make(map[string][]Original)
+	m.canonical = make(map[string]string)
+	m.aliases = make(map[string][]string)
 	m.storeMu.Unlock()
 	m.mu.Lock()
 	m.stats.Source = make([]string, 0)
@@ -325,10 +294,9 @@ func (m *MemoryModel) Clear() error {
 	return nil
 }
 
-// Merge 合并另一个词库
+// Merge 合并另一个词库（来源与元数据一并合并）
 func (m *MemoryModel) Merge(other Store) error {
-	words := other.ReadString()
-	return m.AddWords(words)
+	return m.AddEntries(other.GetEntries())
 }
 
 func (m *MemoryModel) Close() error {
@@ -360,22 +328,32 @@ func (m *MemoryModel) Shutdown(ctx context.Context) error {
 
 // AddWordsWithSource 批量添加词并指定来源
 func (m *MemoryModel) AddWordsWithSource(words []string, source string) error {
+	for _, word := range words {
+		if err := m.checkWordLimit(strings.TrimSpace(word)); err != nil {
+			return err
+		}
+	}
+	added := make([]string, 0, len(words))
 	count := 0
+	// 合并锁：同时保护 store 和 wordSources
+	m.storeMu.Lock()
+	if err := m.checkNewWordsLocked(words); err != nil {
+		m.storeMu.Unlock()
+		return err
+	}
 	for _, word := range words {
 		word = strings.TrimSpace(word)
 		if word == "" {
 			continue
 		}
-		
-		// 合并锁：同时保护 store 和 wordSources
-		m.storeMu.Lock()
 		isNew := !m.storeExists(word)
 		if isNew {
 			m.store[word] = struct{}{}
+			m.index.add(word)
 			m.totalWords.Add(1)
 			count++
 		}
-		
+
 		// 在同一个锁下操作来源映射，避免二次锁
 		if m.wordSources == nil {
 			m.wordSources = make(map[string][]string)
@@ -396,23 +374,11 @@ func (m *MemoryModel) AddWordsWithSource(words []string, source string) error {
 				m.wordSources[word] = append(sources, source)
 			}
 		}
-		m.storeMu.Unlock()
-
-		select {
-		case m.addChan <- word:
-		case <-m.closed:
-			return errors.New("store closed")
-		}
+		added = append(added, word)
 	}
-
-	// 更新统计信息
-	m.mu.Lock()
-	m.stats.TotalWords = int(m.totalWords.Load())
-	m.stats.LastUpdate = time.Now()
-	m.stats.UpdateCount += count
-	m.mu.Unlock()
-
-	return nil
+	m.recordUpdateLocked(count)
+	defer m.storeMu.Unlock()
+	return m.notifyLocked(m.addChan, added)
 }
 
 // GetWordSources 获取指定词的来源列表
@@ -441,6 +407,458 @@ func (m *MemoryModel) GetAllWordSources() map[string][]string {
 	return result
 }
 
+// AddEntries 批量添加词条（含来源、元数据与原始写法）
+// 已存在的词会合并来源与原始写法（去重）并覆盖同名元数据键
+func (m *MemoryModel) AddEntries(entries []Entry) error {
+	return m.putEntries(entries, false, true)
+}
+
+// SetEntries 批量添加或覆盖词条，已存在词的来源、元数据与原始写法会被整体替换
+func (m *MemoryModel) SetEntries(entries []Entry) error {
+	return m.putEntries(entries, true, true)
+}
+
+// PreloadEntries 添加词条但不通知过滤器
+// 用于过滤器已直接挂载包含这些词的匹配结构（如共享自动机）的场景
+func (m *MemoryModel) PreloadEntries(entries []Entry) error {
+	return m.putEntries(entries, false, false)
+}
+
+// putEntries 写入词条，replace 为 true 时先清除已有的附加信息，notify 为 false 时不通知过滤器
+func (m *MemoryModel) putEntries(entries []Entry, replace, notify bool) error {
+	return m.updateEntries(func(func(string) (Entry, bool)) ([]Entry, error) {
+		return entries, nil
+	}, replace, notify)
+}
+
+// UpdateEntries 在词库写锁内以当前词条计算并覆盖写入词条（来源、元数据与原始写法整体替换），读取与写入之间不会插入其他修改
+func (m *MemoryModel) UpdateEntries(update UpdateFunc) error {
+	return m.updateEntries(update, true, true)
+}
+
+func (m *MemoryModel) updateEntries(update UpdateFunc, replace, notify bool) error {
+	m.storeMu.Lock()
+	entries, err := update(func(word string) (Entry, bool) {
+		if !m.storeExists(word) {
+			return Entry{}, false
+		}
+		return m.entryLocked(word), true
+	})
+	if err != nil || len(entries) == 0 {
+		m.storeMu.Unlock()
+		return err
+	}
+	words := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		word := strings.TrimSpace(entry.Word)
+		if err := m.checkWordLimit(word); err != nil {
+			m.storeMu.Unlock()
+			return err
+		}
+		words = append(words, word)
+	}
+	if err := m.checkNewWordsLocked(words); err != nil {
+		m.storeMu.Unlock()
+		return err
+	}
+	added := make([]string, 0, len(entries))
+	count := 0
+	for i, entry := range entries {
+		word := words[i]
+		if word == "" {
+			continue
+		}
+		if !m.storeExists(word) {
+			m.store[word] = struct{}{}
+			m.index.add(word)
+			m.totalWords.Add(1)
+			count++
+		}
+		if replace {
+			m.clearEntryLocked(word)
+		}
+		m.mergeEntryLocked(word, entry)
+		added = append(added, word)
+	}
+	m.recordUpdateLocked(count)
+	defer m.storeMu.Unlock()
+	if notify {
+		return m.notifyLocked(m.addChan, added)
+	}
+	return nil
+}
+
+// mergeEntryLocked 合并词条的来源、元数据与原始写法，调用方需持有 storeMu 写锁
+func (m *MemoryModel) mergeEntryLocked(word string, entry Entry) {
+	for _, source := range entry.Sources {
+		m.addSourceLocked(word, source)
+	}
+	if len(entry.Metadata) > 0 {
+		meta := m.wordMeta[word]
+		if meta == nil {
+			meta = make(map[string]string, len(entry.Metadata))
+			m.wordMeta[word] = meta
+		}
+		for k, v := range entry.Metadata {
+			if _, ok := meta[k]; !ok {
+				m.addMetaKeyLocked(k, 1)
+			}
+			meta[k] = v
+		}
+	}
+	for _, original := range entry.Originals {
+		m.addOriginalLocked(word, original.Text, original.Sources...)
+	}
+	if canonical := strings.TrimSpace(entry.Canonical); canonical != "" {
+		m.bindAliasLocked(word, canonical)
+	}
+}
+
+// clearEntryLocked 清除词的来源、元数据、原始写法及所属别名组，调用方需持有 storeMu 写锁
+// 词作为规范词时，其别名组保持不变
+func (m *MemoryModel) clearEntryLocked(word string) {
+	delete(m.wordSources, word)
+	for k := range m.wordMeta[word] {
+		m.addMetaKeyLocked(k, -1)
+	}
+	delete(m.wordMeta, word)
+	delete(m.originals, word)
+	m.unbindAliasLocked(word)
+}
+
+// addOriginalLocked 记录词的原始写法（按写法去重并合并来源），调用方需持有 storeMu 写锁
+func (m *MemoryModel) addOriginalLocked(word, text string, sources ...string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	list := m.originals[word]
+	for i := range list {
+		if list[i].Text == text {
+			for _, source := range sources {
+				if source != "" && !containsString(list[i].Sources, source) {
+					list[i].Sources = append(list[i].Sources, source)
+				}
+			}
+			return
+		}
+	}
+	original := Original{Text: text}
+	for _, source := range sources {
+		if source != "" && !containsString(original.Sources, source) {
+			original.Sources = append(original.Sources, source)
+		}
+	}
+	m.originals[word] = append(list, original)
+}
+
+// GetEntries 获取所有词条（按词排序，便于导出结果稳定可 diff）
+// 仅有一个与词本身相同、来源也相同的原始写法时省略 Originals，保持导出简洁
+func (m *MemoryModel) GetEntries() []Entry {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	entries := make([]Entry, 0, len(m.store))
+	for word := range m.store {
+		entries = append(entries, m.entryLocked(word))
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Word < entries[j].Word })
+	return entries
+}
+
+// SnapshotEntries 获取所有词条（按词索引）与对应的词库版本号，二者在同一次加锁内读取
+func (m *MemoryModel) SnapshotEntries() (map[string]Entry, uint64) {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	entries := make(map[string]Entry, len(m.store))
+	for word := range m.store {
+		entries[word] = m.entryLocked(word)
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return entries, m.stats.Version
+}
+
+// GetEntry 获取单个词条，词不存在时返回 false
+func (m *MemoryModel) GetEntry(word string) (Entry, bool) {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	if !m.storeExists(word) {
+		return Entry{}, false
+	}
+	return m.entryLocked(word), true
+}
+
+// WordSeq 返回词加入词库的序号，整体替换词库（ResetEntries）后按词条顺序重新编号
+func (m *MemoryModel) WordSeq(word string) (uint64, bool) {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	seq, ok := m.index.seq[word]
+	return seq, ok
+}
+
+// SearchEntries 在词库读锁内按前缀有序检索，条件过滤、计数与分页取自同一时刻的词库
+func (m *MemoryModel) SearchEntries(query WordSearch) WordSearchResult {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	words := m.index.prefix(query.Prefix, m.storeExists)
+	if query.Match != nil {
+		n := 0
+		for _, word := range words {
+			if query.Match(word, m.wordSources[word]) {
+				words[n] = word
+				n++
+			}
+		}
+		words = words[:n]
+	}
+	if query.Desc {
+		for i, j := 0, len(words)-1; i < j; i, j = i+1, j-1 {
+			words[i], words[j] = words[j], words[i]
+		}
+	}
+
+	res := WordSearchResult{Total: len(words)}
+	start := 0
+	if query.After != "" {
+		start = sort.Search(len(words), func(i int) bool {
+			if query.Desc {
+				return words[i] < query.After
+			}
+			return words[i] > query.After
+		})
+	}
+	end := len(words)
+	if query.Limit > 0 && start+query.Limit < end {
+		end = start + query.Limit
+		res.More = true
+	}
+	if start < end {
+		res.Entries = make([]Entry, 0, end-start)
+		for _, word := range words[start:end] {
+			res.Entries = append(res.Entries, m.entryLocked(word))
+		}
+	}
+	return res
+}
+
+// GetMetadata 获取词的单个元数据，词不存在或未设置该键时返回 false
+func (m *MemoryModel) GetMetadata(word, key string) (string, bool) {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	v, ok := m.wordMeta[word][key]
+	return v, ok
+}
+
+// HasMetadataKey 判断是否存在设置了元数据键 key 的词条（无锁，适合匹配时判断是否需要逐词查询元数据）
+func (m *MemoryModel) HasMetadataKey(key string) bool {
+	set := m.metaKeySet.Load()
+	if set == nil {
+		return false
+	}
+	_, ok := (*set)[key]
+	return ok
+}
+
+// addMetaKeyLocked 调整使用元数据键 key 的词条数，键出现或消失时重新发布只读快照，调用方需持有 storeMu 写锁
+func (m *MemoryModel) addMetaKeyLocked(key string, delta int) {
+	n := m.metaKeys[key]
+	m.metaKeys[key] = n + delta
+	if n+delta <= 0 {
+		delete(m.metaKeys, key)
+	}
+	if (n == 0) != (n+delta <= 0) {
+		set := make(map[string]struct{}, len(m.metaKeys))
+		for k := range m.metaKeys {
+			set[k] = struct{}{}
+		}
+		m.metaKeySet.Store(&set)
+	}
+}
+
+// resetMetaKeysLocked 清空元数据键计数，调用方需持有 storeMu 写锁
+func (m *MemoryModel) resetMetaKeysLocked() {
+	m.metaKeys = make(map[string]int)
+	m.metaKeySet.Store(nil)
+}
+
+// entryLocked 构造词条的深拷贝，调用方需持有 storeMu 读锁
+func (m *MemoryModel) entryLocked(word string) Entry {
+	entry := Entry{Word: word}
+	if sources := m.wordSources[word]; len(sources) > 0 {
+		entry.Sources = append([]string{}, sources...)
+	}
+	if meta := m.wordMeta[word]; len(meta) > 0 {
+		entry.Metadata = make(map[string]string, len(meta))
+		for k, v := range meta {
+			entry.Metadata[k] = v
+		}
+	}
+	if originals := m.originals[word]; !trivialOriginals(word, entry.Sources, originals) {
+		entry.Originals = copyOriginals(originals)
+	}
+	entry.Canonical = m.canonical[word]
+	return entry
+}
+
+// GetOriginals 获取词的全部原始写法（首个为规范写法），未记录时返回 nil
+func (m *MemoryModel) GetOriginals(word string) []Original {
+	m.storeMu.RLock()
+	defer m.storeMu.RUnlock()
+	return copyOriginals(m.originals[word])
+}
+
+// trivialOriginals 判断原始写法是否只是词本身（可由词和来源还原）
+func trivialOriginals(word string, sources []string, originals []Original) bool {
+	if len(originals) == 0 {
+		return true
+	}
+	if len(originals) > 1 || originals[0].Text != word || len(originals[0].Sources) != len(sources) {
+		return false
+	}
+	for i := range sources {
+		if originals[0].Sources[i] != sources[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func copyOriginals(originals []Original) []Original {
+	if len(originals) == 0 {
+		return nil
+	}
+	res := make([]Original, len(originals))
+	for i, o := range originals {
+		res[i] = Original{Text: o.Text}
+		if len(o.Sources) > 0 {
+			res[i].Sources = append([]string{}, o.Sources...)
+		}
+	}
+	return res
+}
+
+// ResetEntries 原子替换全部词条（含来源、元数据与原始写法），版本号取 max(当前版本+1, version)，保证递增
+// 限制检查在修改任何状态之前完成；swap 不为 nil 时在词库写锁内调用，过滤器未整体重建时通过通道通知增删差异部分，
+// 已整体重建时在锁内向两个通道各发送一个空字符串作为栅栏（见 filter.Fencer）
+func (m *MemoryModel) ResetEntries(entries []Entry, version uint64, swap ResetFunc) error {
+	words := make([]string, 0, len(entries))
+	set := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		word := strings.TrimSpace(entry.Word)
+		if err := m.checkWordLimit(word); err != nil {
+			return err
+		}
+		if word == "" {
+			continue
+		}
+		if _, ok := set[word]; !ok {
+			set[word] = struct{}{}
+			words = append(words, word)
+		}
+	}
+	if max := int(m.maxWords.Load()); max > 0 && len(words) > max {
+		return &LimitError{Kind: LimitDictWords, Max: max, Actual: len(words)}
+	}
+
+	m.storeMu.Lock()
+	rebuilt := false
+	if swap != nil {
+		var err error
+		if rebuilt, err = swap(words); err != nil {
+			m.storeMu.Unlock()
+			return err
+		}
+	}
+	oldStore := m.store
+	m.store = set
+	m.index.reset(words)
+	m.wordSources = make(map[string][]string)
+	m.wordMeta = make(map[string]map[string]string)
+	m.resetMetaKeysLocked()
+	m.originals = make(map[string][]Original)
+	m.canonical = make(map[string]string)
+	m.aliases = make(map[string][]string)
+	for _, entry := range entries {
+		if word := strings.TrimSpace(entry.Word); word != "" {
+			m.mergeEntryLocked(word, entry)
+		}
+	}
+	m.totalWords.Store(int64(len(set)))
+	m.recordUpdateLocked(1)
+	m.mu.Lock()
+	if version > m.stats.Version {
+		m.stats.Version = version
+	}
+	m.mu.Unlock()
+	defer m.storeMu.Unlock()
+
+	if rebuilt {
+		// 过滤器已整体重建：发送栅栏，此前排队的增删通知均已过时
+		if err := m.notifyLocked(m.delChan, []string{""}); err != nil {
+			return err
+		}
+		return m.notifyLocked(m.addChan, []string{""})
+	}
+
+	// 通知过滤器差异部分
+	var dels []string
+	for word := range oldStore {
+		if _, ok := set[word]; !ok {
+			dels = append(dels, word)
+		}
+	}
+	var adds []string
+	for _, word := range words {
+		if _, ok := oldStore[word]; !ok {
+			adds = append(adds, word)
+		}
+	}
+	if err := m.notifyLocked(m.delChan, dels); err != nil {
+		return err
+	}
+	return m.notifyLocked(m.addChan, adds)
+}
+
+// notifyLocked 通过通道通知过滤器，调用方需持有 storeMu 写锁
+// 在锁内发送保证通道中的通知顺序与词库修改顺序一致，整体替换词库时的栅栏依赖这一点
+func (m *MemoryModel) notifyLocked(ch chan string, words []string) error {
+	for _, word := range words {
+		select {
+		case ch <- word:
+		case <-m.closed:
+			return errors.New("store closed")
+		}
+	}
+	return nil
+}
+
+// containsString 判断切片中是否包含指定字符串
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// addSourceLocked 为词追加来源（去重），调用方需持有 storeMu 写锁
+func (m *MemoryModel) addSourceLocked(word, source string) {
+	if source == "" {
+		return
+	}
+	if !containsString(m.wordSources[word], source) {
+		m.wordSources[word] = append(m.wordSources[word], source)
+	}
+}
+
+// sortedWords 返回按字典序排序的词列表
+func (m *MemoryModel) sortedWords() []string {
+	words := m.ReadString()
+	sort.Strings(words)
+	return words
+}
+
 // storeExists 辅助方法：检查词是否存在
 func (m *MemoryModel) storeExists(word string) bool {
 	_, exists := m.store[word]
@@ -12,6 +12,31 @@ type Stats struct {
 	LastUpdate  time.Time // 最后更新时间
 	UpdateCount int       // 更新次数（添加+删除）
 	Source      []string  // 词库来源（文件路径、URL等）
+	Version     uint64    // 词库版本号（每次变更递增）
+}
+
+// ResetFunc 在 ResetEntries 持有词库写锁期间以新词表调用，用于同时切换过滤器与配置
+// 返回 rebuilt 为 true 表示过滤器已按新词表整体重建（不再通知增删，改为向增删通道各发送一个空字符串作为栅栏），返回错误时不修改词库
+type ResetFunc func(words []string) (rebuilt bool, err error)
+
+// UpdateFunc 在 UpdateEntries 持有词库写锁期间调用：lookup 读取当前词条，返回需要覆盖写入的词条
+// 返回错误时不修改词库；lookup 只能在调用期间使用
+type UpdateFunc func(lookup func(word string) (Entry, bool)) ([]Entry, error)
+
+// WordSearch 词库有序检索条件
+type WordSearch struct {
+	Prefix string                                   // 词前缀，只遍历有序索引中以其开头的区间
+	Match  func(word string, sources []string) bool // 进一步过滤（为 nil 时不过滤），在词库读锁内调用，sources 只读
+	After  string                                   // 只返回排在 After 之后的词（按 Desc 的顺序，为空表示从头开始）
+	Desc   bool                                     // 按词降序
+	Limit  int                                      // 最多返回的词条数
+}
+
+// WordSearchResult 词库有序检索结果，全部取自同一时刻的词库
+type WordSearchResult struct {
+	Entries []Entry // 排在 After 之后的前 Limit 个词条
+	Total   int     // 符合条件的词总数（不考虑 After 与 Limit）
+	More    bool    // 本页之后是否还有符合条件的词
 }
 
 // WordSource 词与来源的映射关系
@@ -20,6 +45,21 @@ type WordSource struct {
 	Source []string // 该词所属的词库来源列表
 }
 
+// Entry 词条的完整信息（词、来源、元数据、原始写法、别名组），用于结构化导入导出
+type Entry struct {
+	Word      string            `json:"word"`                // 敏感词（归一化后）
+	Sources   []string          `json:"sources,omitempty"`   // 该词所属的词库来源列表
+	Metadata  map[string]string `json:"metadata,omitempty"`  // 附加元数据（如等级、备注等）
+	Originals []Original        `json:"originals,omitempty"` // 归一化之前的原始写法（首个为规范写法）
+	Canonical string            `json:"canonical,omitempty"` // 所属别名组的规范词（词本身是规范词或不属于别名组时为空）
+}
+
+// Original 词的原始写法（归一化之前）及其来源
+type Original struct {
+	Text    string   `json:"text"`              // 原始写法
+	Sources []string `json:"sources,omitempty"` // 以该写法添加时的来源
+}
+
 // DictLoaderWithSource 带来源标识的词库加载回调函数类型
 // 返回词列表、来源标识和可能的错误
 type DictLoaderWithSource func() ([]string, string, error)
@@ -37,6 +77,12 @@ type (
 		LoadDict(reader io.Reader) error
 		LoadDictCallback(loader DictLoader, source string) error // 通过回调函数加载词库
 
+		// 带加载报告的词库加载
+		LoadDictWithReport(reader io.Reader, opts LoadOptions) (*LoadReport, error)
+		LoadDictPathWithReport(opts LoadOptions, paths ...string) ([]LoadReport, error)
+		LoadDictEmbedWithReport(opts LoadOptions, contents ...string) ([]LoadReport, error)
+		LastLoadReports() []LoadReport // 最近一次加载调用的报告
+
 		// 读取词库
 		ReadChan() <-chan string
 		ReadString() []string
@@ -49,13 +95,33 @@ type (
 
 		// 批量操作
 		AddWords(words []string) error                  // 批量添加（新增方法，与 AddWord 功能相同但参数更明确）
-		DelWords(words []string) error                  // 批量删除（新增方法）
+		DelWords(words []string) error                  // 批量删除（删除别名组的规范词时整组删除）
 		ReplaceWords(oldWords, newWords []string) error // 批量替换
 
 		// 带来源标识的操作
 		AddWordsWithSource(words []string, source string) error // 批量添加词并指定来源
 		GetWordSources(word string) []string                    // 获取指定词的来源列表
 		GetAllWordSources() map[string][]string                 // 获取所有词的来源映射
+		GetOriginals(word string) []Original                    // 获取词的原始写法（首个为规范写法）
+
+		// 别名组（多个变体词对应同一个规范词）
+		AddAlias(canonical string, variants ...string) error // 将变体绑定到规范词，不存在的词会一并添加
+		GetCanonical(word string) string                     // 获取词所属别名组的规范词（不属于别名组时为词本身）
+		GetAliases(word string) []string                     // 获取词所属别名组的全部变体（不含规范词）
+
+		// 结构化词条（词 + 来源 + 元数据）
+		AddEntries(entries []Entry) error                                   // 批量添加词条，来源与元数据会与已有信息合并
+		SetEntries(entries []Entry) error                                   // 批量添加或覆盖词条，来源与元数据整体替换
+		UpdateEntries(update UpdateFunc) error                              // 在词库写锁内读取当前词条并覆盖写入 update 返回的词条（读改写不会被其他修改打断）
+		GetEntries() []Entry                                                // 获取所有词条（按词排序，结果稳定）
+		GetEntry(word string) (Entry, bool)                                 // 获取单个词条，词不存在时返回 false
+		SearchEntries(query WordSearch) WordSearchResult                    // 在词库读锁内按前缀有序检索并分页（不遍历前缀区间以外的词）
+		WordSeq(word string) (uint64, bool)                                 // 词加入词库的序号（越小越早加入，重新加入的词取新序号），词不存在时返回 false
+		SnapshotEntries() (map[string]Entry, uint64)                        // 获取所有词条（按词索引）与对应的词库版本号（用于快照）
+		GetMetadata(word, key string) (string, bool)                        // 获取词的单个元数据（不复制词条，适合匹配时查询）
+		HasMetadataKey(key string) bool                                     // 是否存在设置了该元数据键的词条（无锁）
+		ResetEntries(entries []Entry, version uint64, swap ResetFunc) error // 原子替换全部词条，swap 在词库锁内切换过滤器（用于恢复备份）
+		PreloadEntries(entries []Entry) error                               // 添加词条但不通知过滤器（过滤器已挂载包含这些词的共享结构）
 
 		// 导出功能
 		ExportToWriter(w io.Writer) error // 导出到 Writer
@@ -66,7 +132,10 @@ type (
 
 		// 词库操作
 		Clear() error            // 清空词库
-		Merge(other Store) error // 合并另一个词库（去重）
+		Merge(other Store) error // 合并另一个词库（去重，保留来源与元数据）
+
+		// 资源限制
+		SetDictLimits(maxWordRunes, maxWords int) // 设置词的最大字符数与词库最大词数（<=0 表示不限制）
 
 		// 生命周期
 		Close() error
@@ -0,0 +1,89 @@
+package go_sensitive_word
+
+import (
+	"unicode/utf8"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/store"
+)
+
+// Limits 资源限制，各字段 <= 0 表示不限制
+type Limits struct {
+	MaxInputRunes int // 单次检测的最大输入字符数：只检测前 MaxInputRunes 个字符（截断），其余原文原样保留
+	MaxMatches    int // 单次调用的最大命中数（按匹配模式选取后的出现次数）：只保留前 MaxMatches 个命中（封顶）
+	MaxWordRunes  int // 词的最大字符数（归一化后）：添加超长词时整批拒绝并返回 *LimitError，加载词库时记为 RejectTooLong
+	MaxDictWords  int // 词库最大词数：写入后超出上限时整批拒绝并返回 *LimitError（不写入任何词）
+}
+
+type (
+	// LimitError 超出资源限制
+	// 截断与封顶不影响普通检测方法的返回值，*Context 方法会在返回结果的同时返回 *LimitError
+	LimitError = store.LimitError
+	// LimitKind 资源限制类型
+	LimitKind = store.LimitKind
+)
+
+const (
+	LimitInputRunes = store.LimitInputRunes // 输入字符数超出 MaxInputRunes
+	LimitMatches    = store.LimitMatches    // 命中数超出 MaxMatches
+	LimitWordRunes  = store.LimitWordRunes  // 词的字符数超出 MaxWordRunes
+	LimitDictWords  = store.LimitDictWords  // 词库词数超出 MaxDictWords
+)
+
+// SetLimits 设置资源限制（整体替换），对之后的检测与词库修改生效，已在词库中的词不受影响
+func (m *Manager) SetLimits(l Limits) {
+	m.nf.lim.Store(&l)
+	if m.Store != nil {
+		m.Store.SetDictLimits(l.MaxWordRunes, l.MaxDictWords)
+	}
+}
+
+// Limits 返回当前的资源限制
+func (m *Manager) Limits() Limits {
+	return m.nf.limits()
+}
+
+func (nf *normalizedFilter) limits() Limits {
+	if l := nf.lim.Load(); l != nil {
+		return *l
+	}
+	return Limits{}
+}
+
+// limitInput 按 MaxInputRunes 截断输入，超出时返回检测的前缀与 *LimitError
+func (nf *normalizedFilter) limitInput(text string) (string, error) {
+	max := nf.limits().MaxInputRunes
+	if max <= 0 || len(text) <= max {
+		return text, nil
+	}
+	n := 0
+	for i := range text {
+		if n == max {
+			return text[:i], &LimitError{Kind: LimitInputRunes, Max: max, Actual: utf8.RuneCountInString(text)}
+		}
+		n++
+	}
+	return text, nil
+}
+
+// limitInputBytes 同 limitInput，作用于字节切片
+func (nf *normalizedFilter) limitInputBytes(src []byte) []byte {
+	max := nf.limits().MaxInputRunes
+	if max <= 0 || len(src) <= max {
+		return src
+	}
+	pos := 0
+	for n := 0; n < max && pos < len(src); n++ {
+		_, size := utf8.DecodeRune(src[pos:])
+		pos += size
+	}
+	return src[:pos]
+}
+
+// limitHits 按 MaxMatches 封顶命中，超出时返回前 MaxMatches 个命中与 *LimitError
+func (nf *normalizedFilter) limitHits(hits []hit) ([]hit, error) {
+	max := nf.limits().MaxMatches
+	if max <= 0 || len(hits) <= max {
+		return hits, nil
+	}
+	return hits[:max], &LimitError{Kind: LimitMatches, Max: max, Actual: len(hits)}
+}
@@ -0,0 +1,78 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLimits_InputRunes(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "赌博", "毒品")
+		m.SetLimits(Limits{MaxInputRunes: 10})
+
+		// 输入截断：只检测前 10 个字符，其余原样保留
+		text := "赌博赌博赌博赌博赌博毒品"
+		if got := m.Replace(text, '*'); got != "**********毒品" {
+			t.Fatalf("truncated replace: %q", got)
+		}
+		if m.IsSensitive(strings.Repeat("正常", 5) + "毒品") {
+			t.Fatal("input beyond limit detected")
+		}
+		if m.IsSensitiveBytes([]byte(strings.Repeat("正常", 5) + "毒品")) {
+			t.Fatal("byte input beyond limit detected")
+		}
+		var limitErr *LimitError
+		if _, err := m.FindAllContext(context.Background(), text); !errors.As(err, &limitErr) || limitErr.Kind != LimitInputRunes || limitErr.Actual != 12 {
+			t.Fatalf("input limit error: %v", err)
+		}
+	})
+}
+
+func TestLimits_Matches(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "赌博", "毒品")
+		// 命中封顶
+		m.SetLimits(Limits{MaxMatches: 3})
+		if got := m.FindAllCount(strings.Repeat("赌博", 10)); got["赌博"] != 3 {
+			t.Fatalf("capped count: %v", got)
+		}
+		var limitErr *LimitError
+		if _, err := m.ReplaceContext(context.Background(), strings.Repeat("赌博", 10), '*'); !errors.As(err, &limitErr) || limitErr.Kind != LimitMatches {
+			t.Fatalf("match limit error: %v", err)
+		}
+		if _, err := m.FindAllContext(context.Background(), strings.Repeat("赌博", 3)); err != nil {
+			t.Fatalf("within limit: %v", err)
+		}
+	})
+}
+
+func TestLimits_Dictionary(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "赌博", "毒品")
+	// 词长与词库大小
+	m.SetLimits(Limits{MaxWordRunes: 4, MaxDictWords: 3})
+	var limitErr *LimitError
+	if err := m.AddWords([]string{"枪支", "超长的敏感词"}); !errors.As(err, &limitErr) || limitErr.Kind != LimitWordRunes {
+		t.Fatalf("word limit error: %v", err)
+	}
+	if m.GetStats().TotalWords != 2 {
+		t.Fatal("rejected batch partially applied")
+	}
+	if err := m.AddWords([]string{"枪支", "弹药"}); !errors.As(err, &limitErr) || limitErr.Kind != LimitDictWords {
+		t.Fatalf("dict limit error: %v", err)
+	}
+	if got := m.GetStats().TotalWords; got != 2 {
+		t.Fatalf("dict limit batch partially applied: %d words", got)
+	}
+	if err := m.AddWords([]string{"枪支"}); err != nil {
+		t.Fatalf("word within dict limit rejected: %v", err)
+	}
+	if err := m.AddWords([]string{"赌博"}); err != nil {
+		t.Fatalf("existing word rejected: %v", err)
+	}
+	report, err := m.LoadDictWithReport(strings.NewReader("超长的敏感词\n赌博\n"), LoadOptions{})
+	if err != nil || len(report.Rejected) != 1 || report.Rejected[0].Reason != RejectTooLong {
+		t.Fatalf("load report: %+v, %v", report, err)
+	}
+}
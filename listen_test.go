@@ -0,0 +1,61 @@
+package go_sensitive_word
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 以下测试只通过公开 API 写入词库，由后台监听协程将增删同步到过滤器，
+// 覆盖其他测试经 newTestManager 同步载入时跳过的异步路径
+
+func TestListen_AddWord(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft})
+		if err := m.AddWord("赌博", "SB"); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.AddWordsWithSource([]string{"毒品"}, "custom"); err != nil {
+			t.Fatal(err)
+		}
+		waitForListener(t, m)
+
+		text := "赌博 和 毒品 还有 sb"
+		if got := m.FindAll(text); !reflect.DeepEqual(got, []string{"赌博", "毒品", "sb"}) {
+			t.Fatalf("FindAll: %v", got)
+		}
+		if got := m.Replace(text, '*'); got != "** 和 ** 还有 **" {
+			t.Fatalf("Replace: %q", got)
+		}
+		if got := m.FindAllWithSource(text); len(got) != 3 || !reflect.DeepEqual(got[1].Source, []string{"custom"}) {
+			t.Fatalf("FindAllWithSource: %+v", got)
+		}
+
+		if err := m.DelWord("赌博"); err != nil {
+			t.Fatal(err)
+		}
+		waitForRemoved(t, m, "赌博")
+		if got := m.FindAll(text); !reflect.DeepEqual(got, []string{"毒品", "sb"}) {
+			t.Fatalf("FindAll after delete: %v", got)
+		}
+	})
+}
+
+func TestListen_Import(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft})
+		data := `{"word":"违禁词","sources":["custom"],"metadata":{"level":"high"}}` + "\n" + `{"word":"BadWord"}` + "\n"
+		if err := m.Import(strings.NewReader(data), FormatJSONLines); err != nil {
+			t.Fatal(err)
+		}
+		waitForListener(t, m)
+
+		if got := m.FindAll("违禁词 badword"); !reflect.DeepEqual(got, []string{"违禁词", "badword"}) {
+			t.Fatalf("FindAll: %v", got)
+		}
+		entry, ok := m.GetEntry("违禁词")
+		if !ok || entry.Metadata["level"] != "high" {
+			t.Fatalf("entry: %+v", entry)
+		}
+	})
+}
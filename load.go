@@ -0,0 +1,63 @@
+package go_sensitive_word
+
+import (
+	"errors"
+	"io"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/store"
+)
+
+type (
+	// LoadReport 一次词库加载的报告（读取行数、新增词数、重复词数、被拒绝的行）
+	LoadReport = store.LoadReport
+	// LoadOptions 词库加载选项（校验规则、是否全部成功才写入、来源标识）
+	LoadOptions = store.LoadOptions
+	// RejectedLine 校验未通过的行
+	RejectedLine = store.RejectedLine
+	// RejectReason 校验未通过的原因
+	RejectReason = store.RejectReason
+)
+
+// 词条校验未通过的原因
+const (
+	RejectEmpty       = store.RejectEmpty       // 归一化后为空
+	RejectTooLong     = store.RejectTooLong     // 超过最大长度
+	RejectControlChar = store.RejectControlChar // 包含控制字符
+)
+
+// withNormalize 未指定归一化函数时，使用 Manager 的归一化配置
+func (m *Manager) withNormalize(opts LoadOptions) LoadOptions {
+	if opts.Normalize == nil {
+		cfg := m.Normalizer()
+		opts.Normalize = func(word string) string { return NormalizeWord(word, cfg) }
+	}
+	return opts
+}
+
+// LoadDictWithReport 从 Reader 加载词库并返回加载报告
+// 注意：未指定 opts.Normalize 时，词按 Manager 的归一化配置归一化
+func (m *Manager) LoadDictWithReport(reader io.Reader, opts LoadOptions) (*LoadReport, error) {
+	if m.Store == nil {
+		return nil, errors.New("store is nil")
+	}
+	return m.Store.LoadDictWithReport(reader, m.withNormalize(opts))
+}
+
+// LoadDictPathWithReport 从多个文件加载词库并返回每个文件的加载报告
+// opts.Atomic 为 true 时，任一文件读取失败都不会写入任何词
+// 注意：未指定 opts.Normalize 时，词按 Manager 的归一化配置归一化
+func (m *Manager) LoadDictPathWithReport(opts LoadOptions, paths ...string) ([]LoadReport, error) {
+	if m.Store == nil {
+		return nil, errors.New("store is nil")
+	}
+	return m.Store.LoadDictPathWithReport(m.withNormalize(opts), paths...)
+}
+
+// LoadDictEmbedWithReport 加载内置词库内容并返回每份内容的加载报告
+// 注意：未指定 opts.Normalize 时，词按 Manager 的归一化配置归一化
+func (m *Manager) LoadDictEmbedWithReport(opts LoadOptions, contents ...string) ([]LoadReport, error) {
+	if m.Store == nil {
+		return nil, errors.New("store is nil")
+	}
+	return m.Store.LoadDictEmbedWithReport(m.withNormalize(opts), contents...)
+}
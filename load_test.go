@@ -0,0 +1,72 @@
+package go_sensitive_word
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadDictWithReport(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "已有词")
+	content := "新词\n\n已有词\nＡＢＣ\nabc\n控制\x01字符\n超长的一个词语\n词\t语\n"
+	report, err := m.LoadDictWithReport(strings.NewReader(content), LoadOptions{MaxWordLength: 6, Source: "upload"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Lines != 8 || report.Blank != 1 || report.NewWords != 2 || report.Duplicates != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	want := []RejectedLine{
+		{Line: 6, Text: "控制\x01字符", Reason: RejectControlChar},
+		{Line: 7, Text: "超长的一个词语", Reason: RejectTooLong},
+		{Line: 8, Text: "词\t语", Reason: RejectControlChar},
+	}
+	if !reflect.DeepEqual(report.Rejected, want) {
+		t.Fatalf("unexpected rejected lines: %+v", report.Rejected)
+	}
+	if got := m.GetWordSources("abc"); !reflect.DeepEqual(got, []string{"upload"}) {
+		t.Fatalf("expect source recorded, got %v", got)
+	}
+	if got := m.LastLoadReports(); len(got) != 1 || got[0].NewWords != 2 {
+		t.Fatalf("unexpected last reports: %+v", got)
+	}
+}
+
+func TestLoadDictPathWithReport_Atomic(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC})
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.txt")
+	if err := os.WriteFile(good, []byte("词一\n词二\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.txt")
+
+	if _, err := m.LoadDictPathWithReport(LoadOptions{Atomic: true}, good, missing); err == nil {
+		t.Fatal("expect error for missing file")
+	}
+	if n := m.GetStats().TotalWords; n != 0 {
+		t.Fatalf("atomic load should not add words, got %d", n)
+	}
+
+	if _, err := m.LoadDictPathWithReport(LoadOptions{}, good, missing); err == nil {
+		t.Fatal("expect error for missing file")
+	}
+	if n := m.GetStats().TotalWords; n != 2 {
+		t.Fatalf("non-atomic load should keep earlier files, got %d", n)
+	}
+
+	// 超出词库上限时原子加载整体拒绝
+	other := filepath.Join(dir, "other.txt")
+	if err := os.WriteFile(other, []byte("词三\n词四\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.SetLimits(Limits{MaxDictWords: 3})
+	if _, err := m.LoadDictPathWithReport(LoadOptions{Atomic: true}, good, other); err == nil {
+		t.Fatal("expect dict limit error")
+	}
+	if n := m.GetStats().TotalWords; n != 2 {
+		t.Fatalf("atomic load over dict limit should not add words, got %d", n)
+	}
+}
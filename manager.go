@@ -5,18 +5,25 @@ import (
 	"errors"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/LuYongwang/go-sensitive-word/internal/filter"
 	"github.com/LuYongwang/go-sensitive-word/internal/filter/ac"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/dat"
 	"github.com/LuYongwang/go-sensitive-word/internal/filter/dfa"
 	"github.com/LuYongwang/go-sensitive-word/internal/store"
 )
 
 // Manager 是敏感词过滤系统的核心结构，整合了词库存储和过滤算法
 type Manager struct {
-	store.Store                    // 词库存储接口（支持内存、本地文件、远程等）
-	filter.Filter                  // 敏感词匹配算法接口（如 DFA、Aho-Corasick）
-	normalizer    NormalizerConfig // 归一化配置，用于确保词库的词和测试文本的归一化一致
+	store.Store                     // 词库存储接口（支持内存、本地文件、远程等）
+	filter.Filter                   // 敏感词匹配算法接口（如 DFA、Aho-Corasick）
+	nf            *normalizedFilter // 归一化包装器，持有归一化配置与白名单，确保词库的词和测试文本的归一化一致
+	allowMu       sync.Mutex        // 串行化白名单修改
+	restoreMu     sync.Mutex        // 串行化备份恢复
+	tenantMu      sync.Mutex        // 保护 tenants
+	tenants       map[string]*Tenant
+	releaseShared func() // 释放共享自动机的引用（由 restoreMu 保护）
 }
 
 // NewFilter 初始化过滤器和词库存储
@@ -42,23 +49,49 @@ func NewFilter(storeOption StoreOption, filterOption FilterOption) (*Manager, er
 		acModel := ac.NewACModel()
 		go acModel.Listen(filterStore.GetAddChan(), filterStore.GetDelChan())
 		myFilter = acModel
+	case FilterDAT: // 使用双数组 AC 自动机
+		datModel := dat.NewDATModel()
+		go datModel.Listen(filterStore.GetAddChan(), filterStore.GetDelChan())
+		myFilter = datModel
 	default:
 		return nil, errors.New("invalid filter type")
 	}
 
 	// 默认开启大小写与全角归一化，使匹配对大小写/全角不敏感
 	normalizerCfg := DefaultNormalizer()
+	if filterOption.Normalizer != nil {
+		normalizerCfg = *filterOption.Normalizer
+	}
 	wrapped := newNormalizedFilter(myFilter, normalizerCfg)
+	wrapped.par = filterOption.Parallel
+	wrapped.mode = filterOption.MatchMode
+	wrapped.bound = filterOption.WordBoundary
+	if filterOption.Prefilter {
+		if err := wrapped.enablePrefilter(); err != nil {
+			return nil, err
+		}
+	}
 
+	wrapped.rules, wrapped.seqs = filterStore, filterStore
 	return &Manager{
-		Store:      filterStore,
-		Filter:     wrapped,
-		normalizer: normalizerCfg,
+		Store:  filterStore,
+		Filter: wrapped,
+		nf:     wrapped,
 	}, nil
 }
 
-// Close 关闭内部资源
+// Normalizer 返回当前的归一化配置
+func (m *Manager) Normalizer() NormalizerConfig {
+	return m.nf.config()
+}
+
+// Close 关闭内部资源（同时释放共享自动机的引用）
 func (m *Manager) Close() error {
+	m.restoreMu.Lock()
+	if m.releaseShared != nil {
+		m.releaseShared()
+	}
+	m.restoreMu.Unlock()
 	if m.Store != nil {
 		if c, ok := m.Store.(interface{ Close() error }); ok {
 			return c.Close()
@@ -93,18 +126,12 @@ func (m *Manager) AddWord(words ...string) error {
 	if m.Store == nil {
 		return errors.New("store is nil")
 	}
-	// 对词进行归一化，确保词库的词和测试文本的归一化一致
-	normalizedWords := make([]string, 0, len(words))
-	for _, word := range words {
-		normalized := NormalizeWord(word, m.normalizer)
-		if normalized != "" {
-			normalizedWords = append(normalizedWords, normalized)
-		}
-	}
-	if len(normalizedWords) == 0 {
+	// 对词进行归一化，确保词库的词和测试文本的归一化一致，同时记录原始写法
+	entries := m.rawEntries(words, "")
+	if len(entries) == 0 {
 		return nil
 	}
-	return m.Store.AddWords(normalizedWords)
+	return m.Store.AddEntries(entries)
 }
 
 // AddWords 批量添加敏感词
@@ -113,18 +140,12 @@ func (m *Manager) AddWords(words []string) error {
 	if m.Store == nil {
 		return errors.New("store is nil")
 	}
-	// 对词进行归一化，确保词库的词和测试文本的归一化一致
-	normalizedWords := make([]string, 0, len(words))
-	for _, word := range words {
-		normalized := NormalizeWord(word, m.normalizer)
-		if normalized != "" {
-			normalizedWords = append(normalizedWords, normalized)
-		}
-	}
-	if len(normalizedWords) == 0 {
+	// 对词进行归一化，确保词库的词和测试文本的归一化一致，同时记录原始写法
+	entries := m.rawEntries(words, "")
+	if len(entries) == 0 {
 		return nil
 	}
-	return m.Store.AddWords(normalizedWords)
+	return m.Store.AddEntries(entries)
 }
 
 // DelWord 删除敏感词（支持多个）
@@ -136,7 +157,7 @@ func (m *Manager) DelWord(words ...string) error {
 	// 对词进行归一化
 	normalizedWords := make([]string, 0, len(words))
 	for _, word := range words {
-		normalized := NormalizeWord(word, m.normalizer)
+		normalized := NormalizeWord(word, m.Normalizer())
 		if normalized != "" {
 			normalizedWords = append(normalizedWords, normalized)
 		}
@@ -156,7 +177,7 @@ func (m *Manager) DelWords(words []string) error {
 	// 对词进行归一化
 	normalizedWords := make([]string, 0, len(words))
 	for _, word := range words {
-		normalized := NormalizeWord(word, m.normalizer)
+		normalized := NormalizeWord(word, m.Normalizer())
 		if normalized != "" {
 			normalizedWords = append(normalizedWords, normalized)
 		}
@@ -176,14 +197,14 @@ func (m *Manager) ReplaceWords(oldWords, newWords []string) error {
 	// 对词进行归一化
 	normalizedOldWords := make([]string, 0, len(oldWords))
 	for _, word := range oldWords {
-		normalized := NormalizeWord(word, m.normalizer)
+		normalized := NormalizeWord(word, m.Normalizer())
 		if normalized != "" {
 			normalizedOldWords = append(normalizedOldWords, normalized)
 		}
 	}
 	normalizedNewWords := make([]string, 0, len(newWords))
 	for _, word := range newWords {
-		normalized := NormalizeWord(word, m.normalizer)
+		normalized := NormalizeWord(word, m.Normalizer())
 		if normalized != "" {
 			normalizedNewWords = append(normalizedNewWords, normalized)
 		}
@@ -224,12 +245,11 @@ func (m *Manager) Clear() error {
 	return m.Store.Clear()
 }
 
-// MergeFromManager 从另一个 Manager 合并词库
+// MergeFromManager 从另一个 Manager 合并词库（取并集，保留来源与元数据）
+// 需要冲突策略或合并报告时使用 MergeWith
 func (m *Manager) MergeFromManager(other *Manager) error {
-	if m.Store == nil || other == nil || other.Store == nil {
-		return errors.New("invalid store")
-	}
-	return m.Merge(other.Store)
+	_, err := m.MergeWith(other, MergeOptions{Policy: MergeUnion})
+	return err
 }
 
 // RefreshFromPath 从文件路径刷新词库（可选：完全替换或追加）
@@ -286,18 +306,12 @@ func (m *Manager) AddWordsWithSource(words []string, source string) error {
 	if m.Store == nil {
 		return errors.New("store is nil")
 	}
-	// 对词进行归一化
-	normalizedWords := make([]string, 0, len(words))
-	for _, word := range words {
-		normalized := NormalizeWord(word, m.normalizer)
-		if normalized != "" {
-			normalizedWords = append(normalizedWords, normalized)
-		}
-	}
-	if len(normalizedWords) == 0 {
+	// 对词进行归一化，同时记录原始写法
+	entries := m.rawEntries(words, source)
+	if len(entries) == 0 {
 		return nil
 	}
-	return m.Store.AddWordsWithSource(normalizedWords, source)
+	return m.Store.AddEntries(entries)
 }
 
 // GetWordSources 获取指定词的来源列表
@@ -305,7 +319,7 @@ func (m *Manager) GetWordSources(word string) []string {
 	if m.Store == nil {
 		return nil
 	}
-	normalized := NormalizeWord(word, m.normalizer)
+	normalized := NormalizeWord(word, m.Normalizer())
 	return m.Store.GetWordSources(normalized)
 }
 
@@ -319,37 +333,10 @@ func (m *Manager) GetAllWordSources() map[string][]string {
 
 // FindAllWithSource 查找文本中所有敏感词及其来源信息
 func (m *Manager) FindAllWithSource(text string) []filter.MatchResult {
-	words := m.FindAll(text)
-	if len(words) == 0 {
-		return []filter.MatchResult{}
-	}
-
-	result := make([]filter.MatchResult, 0, len(words))
-	seen := make(map[string]bool)
-	for _, word := range words {
-		if seen[word] {
-			continue
-		}
-		seen[word] = true
-		sources := m.GetWordSources(word)
-		result = append(result, filter.MatchResult{
-			Word:   word,
-			Source: sources,
-		})
-	}
-	return result
+	return findAllWithSource(m.Filter, text, m.matchResult)
 }
 
 // FindAllCountWithSource 查找所有敏感词及其出现次数和来源信息
 func (m *Manager) FindAllCountWithSource(text string) map[string]filter.MatchResult {
-	countMap := m.FindAllCount(text)
-	result := make(map[string]filter.MatchResult, len(countMap))
-	for word := range countMap {
-		sources := m.GetWordSources(word)
-		result[word] = filter.MatchResult{
-			Word:   word,
-			Source: sources,
-		}
-	}
-	return result
+	return findAllCountWithSource(m.Filter, text, m.matchResult)
 }
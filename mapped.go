@@ -0,0 +1,111 @@
+package go_sensitive_word
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/flat"
+)
+
+// mappedMeta 扁平自动机文件附带的元数据
+type mappedMeta struct {
+	NormalizerVersion int              `json:"normalizer_version"`
+	Normalizer        NormalizerConfig `json:"normalizer"`
+	Words             int              `json:"words"`
+	AllowWords        []string         `json:"allow_words,omitempty"`
+}
+
+// MappedFilter 基于内存映射文件的只读过滤器，适用于数百万短语的超大词库
+// 自动机以定长记录存放在文件中，匹配直接读取映射内存，无需反序列化；
+// 多个进程打开同一文件时共享页缓存。非 Linux 平台退化为一次性读入内存
+// 查询方法与 Manager 一致（归一化、白名单、原文区间映射），但不支持修改词库
+type MappedFilter struct {
+	filter.Filter
+	automaton *flat.Automaton
+	meta      mappedMeta
+}
+
+// WriteMapped 将当前词库与白名单写成扁平自动机文件格式，供 OpenMapped 使用
+// 自动机按当前词库完整构建，不依赖后台同步进度
+func (m *Manager) WriteMapped(w io.Writer) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	entries := m.Store.GetEntries()
+	words := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		words = append(words, entry.Word)
+	}
+	return writeMapped(w, words, m.Normalizer(), m.GetAllowWords())
+}
+
+// WriteMappedWords 不经过 Manager，直接将词表写成扁平自动机文件格式
+// 词按 cfg 归一化后写入；适合构建无法整体放入 Manager 的超大词库
+func WriteMappedWords(w io.Writer, words []string, cfg NormalizerConfig) error {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		if n := NormalizeWord(word, cfg); n != "" {
+			normalized = append(normalized, n)
+		}
+	}
+	return writeMapped(w, normalized, cfg, nil)
+}
+
+func writeMapped(w io.Writer, words []string, cfg NormalizerConfig, allowWords []string) error {
+	meta, err := json.Marshal(mappedMeta{
+		NormalizerVersion: NormalizerVersion,
+		Normalizer:        cfg,
+		Words:             len(words),
+		AllowWords:        allowWords,
+	})
+	if err != nil {
+		return err
+	}
+	return flat.Write(w, words, meta)
+}
+
+// OpenMapped 以只读方式打开 WriteMapped/WriteMappedWords 生成的文件
+// 只校验文件头与长度，不读取整个文件；需要完整校验时调用 Verify
+// 使用完毕后需调用 Close 解除映射
+func OpenMapped(path string) (*MappedFilter, error) {
+	automaton, err := flat.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	var meta mappedMeta
+	if err := json.Unmarshal(automaton.Meta(), &meta); err != nil {
+		_ = automaton.Close()
+		return nil, fmt.Errorf("decode mapped automaton metadata: %w", err)
+	}
+	if meta.NormalizerVersion != NormalizerVersion {
+		_ = automaton.Close()
+		return nil, fmt.Errorf("%w: mapped automaton built with normalizer version %d, current version is %d; regenerate the file",
+			ErrNormalizerVersion, meta.NormalizerVersion, NormalizerVersion)
+	}
+	nf := newNormalizedFilter(automaton, meta.Normalizer)
+	nf.allow.Store(newAllowList(meta.AllowWords))
+	return &MappedFilter{Filter: nf, automaton: automaton, meta: meta}, nil
+}
+
+// Normalizer 返回文件中记录的归一化配置
+func (f *MappedFilter) Normalizer() NormalizerConfig {
+	return f.meta.Normalizer
+}
+
+// WordCount 返回文件中的词数
+func (f *MappedFilter) WordCount() int {
+	return f.meta.Words
+}
+
+// Verify 校验整个文件的 CRC32（会读取全部数据）
+func (f *MappedFilter) Verify() error {
+	return f.automaton.Verify()
+}
+
+// Close 解除内存映射，之后的查询不再命中任何词
+func (f *MappedFilter) Close() error {
+	return f.automaton.Close()
+}
@@ -0,0 +1,138 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// testMappedWords 写入映射文件的测试词表
+var testMappedWords = []string{"he", "she", "his", "hers", "赌博", "赌博机", "SEX"}
+
+// openTestMapped 将 m 的词库写入映射文件并打开
+func openTestMapped(t *testing.T, m *Manager) *MappedFilter {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dict.flat")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteMapped(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mf, err := OpenMapped(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = mf.Close() })
+	return mf
+}
+
+// newMappedManager 创建包含测试词表与白名单的 Manager
+func newMappedManager(t *testing.T) *Manager {
+	t.Helper()
+	m := newTestManager(t, FilterOption{Type: FilterAC}, testMappedWords...)
+	if err := m.AddAllowWords("Essex"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMappedFilter(t *testing.T) {
+	m := newMappedManager(t)
+	mf := openTestMapped(t, m)
+	if err := mf.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if mf.WordCount() != len(testMappedWords) {
+		t.Fatalf("word count: %d", mf.WordCount())
+	}
+	for _, text := range []string{"ushers", "他在玩赌博机和赌博", "SEX Essex sex", "无敏感词", ""} {
+		if got, want := mf.FindAll(text), m.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Fatalf("find all %q: %v, want %v", text, got, want)
+		}
+		if got, want := mf.FindAllCount(text), m.FindAllCount(text); !reflect.DeepEqual(got, want) {
+			t.Fatalf("find all count %q: %v, want %v", text, got, want)
+		}
+		if got, want := mf.Replace(text, '*'), m.Replace(text, '*'); got != want {
+			t.Fatalf("replace %q: %q, want %q", text, got, want)
+		}
+		if got, want := mf.IsSensitive(text), m.IsSensitive(text); got != want {
+			t.Fatalf("is sensitive %q: %v, want %v", text, got, want)
+		}
+	}
+}
+
+func TestMappedFilter_Closed(t *testing.T) {
+	mf := openTestMapped(t, newMappedManager(t))
+	if err := mf.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if mf.IsSensitive("赌博") {
+		t.Fatal("closed filter should not match")
+	}
+}
+
+// writeTestMappedWords 直接从词表写入映射文件，返回文件路径与内容
+func writeTestMappedWords(t *testing.T) (string, []byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := WriteMappedWords(&buf, []string{"ＡＢＣ"}, DefaultNormalizer()); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "words.flat")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path, buf.Bytes()
+}
+
+func TestWriteMappedWords(t *testing.T) {
+	path, _ := writeTestMappedWords(t)
+	mf, err := OpenMapped(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mf.Close()
+	if got := mf.FindOne("xabcx"); got != "abc" {
+		t.Fatalf("find one: %q", got)
+	}
+}
+
+func TestMappedFilter_Corrupted(t *testing.T) {
+	path, data := writeTestMappedWords(t)
+	// 数据损坏：长度不一致时拒绝打开，内容损坏时 Verify 报错
+	if err := os.WriteFile(path, data[:len(data)-1], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenMapped(path); err == nil {
+		t.Fatal("expected error for truncated file")
+	}
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	if err := os.WriteFile(path, corrupted, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mf, err := OpenMapped(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mf.Close()
+	if err := mf.Verify(); err == nil {
+		t.Fatal("expected checksum error")
+	}
+	mf.FindAll("abc") // 损坏的数据不应导致越界
+}
+
+func TestOpenMapped_Missing(t *testing.T) {
+	if _, err := OpenMapped(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("missing file: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package go_sensitive_word
+
+import (
+	"math"
+	"sort"
+)
+
+// MatchMode 命中的选取方式，FindAll、FindAllCount、Replace、Remove（含 Context 与字节切片版本）按同一规则选取命中
+// 选取基于原文区间进行，与底层算法（DFA、AC、双数组 AC）无关，结果一致
+type MatchMode uint32
+
+const (
+	// MatchOverlapping 保留全部命中，包括相互重叠的命中与同一个词的重叠出现（默认）
+	MatchOverlapping MatchMode = iota
+	// MatchLeftmostLongest 从左到右选取起始位置最靠左的命中，同一起点取最长的词，之后从该命中结束处继续
+	MatchLeftmostLongest
+	// MatchLeftmostFirst 同 MatchLeftmostLongest，但同一起点取最先加入词库的词（词库顺序优先，与词长无关）
+	// 不在词库中的词（如租户叠加词）排在词库词之后；恢复备份或加载编译词库后按词条顺序重新编号
+	MatchLeftmostFirst
+	// MatchNonOverlapping 按结束位置从左到右选取最先结束的命中（同一结束位置取最长的词），跳过与已选命中重叠的命中
+	MatchNonOverlapping
+)
+
+// String 返回匹配模式名称
+func (m MatchMode) String() string {
+	switch m {
+	case MatchOverlapping:
+		return "overlapping"
+	case MatchLeftmostLongest:
+		return "leftmost-longest"
+	case MatchLeftmostFirst:
+		return "leftmost-first"
+	case MatchNonOverlapping:
+		return "non-overlapping"
+	}
+	return "unknown"
+}
+
+// wordSeqs 词加入词库的顺序来源（词库存储）
+type wordSeqs interface {
+	WordSeq(word string) (uint64, bool)
+}
+
+// seqOf 返回规范化词加入词库的序号，不在词库中的词排在所有词库词之后
+func (nf *normalizedFilter) seqOf(word string) uint64 {
+	if nf.seqs != nil {
+		if seq, ok := nf.seqs.WordSeq(word); ok {
+			return seq
+		}
+	}
+	return math.MaxUint64
+}
+
+// selectHits 按匹配模式选取命中，结果按结束位置排序（同一结束位置按词长从长到短）
+// seqOf 返回词加入词库的序号，仅 MatchLeftmostFirst 使用
+func selectHits(hits []hit, mode MatchMode, seqOf func(string) uint64) []hit {
+	if len(hits) < 2 {
+		return hits
+	}
+	byEnd := func(i, j int) bool {
+		if hits[i].end != hits[j].end {
+			return hits[i].end < hits[j].end
+		}
+		return hits[i].start < hits[j].start
+	}
+	// 单遍匹配的命中已按该顺序排列；DFA 等按起始位置输出的过滤器需要重新排序
+	if !sort.SliceIsSorted(hits, byEnd) {
+		sort.SliceStable(hits, byEnd)
+	}
+	switch mode {
+	case MatchLeftmostLongest, MatchLeftmostFirst:
+		byStart := append([]hit(nil), hits...)
+		var seqs map[string]uint64
+		if mode == MatchLeftmostFirst {
+			seqs = make(map[string]uint64)
+			for _, h := range byStart {
+				if _, ok := seqs[h.word]; !ok {
+					seqs[h.word] = seqOf(h.word)
+				}
+			}
+		}
+		sort.SliceStable(byStart, func(i, j int) bool {
+			a, b := byStart[i], byStart[j]
+			if a.start != b.start {
+				return a.start < b.start
+			}
+			if sa, sb := seqs[a.word], seqs[b.word]; sa != sb {
+				return sa < sb
+			}
+			return a.end > b.end
+		})
+		return pickDisjoint(byStart)
+	case MatchNonOverlapping:
+		return pickDisjoint(hits)
+	}
+	return hits
+}
+
+// pickDisjoint 依次选取与已选命中不重叠的命中
+func pickDisjoint(hits []hit) []hit {
+	res := hits[:0:0]
+	next := 0
+	for _, h := range hits {
+		if h.start >= next {
+			res = append(res, h)
+			next = h.end
+		}
+	}
+	return res
+}
+
+// pickHits 按匹配模式选取命中后再按 MaxMatches 封顶，超出时返回 *LimitError
+func (nf *normalizedFilter) pickHits(hits []hit) ([]hit, error) {
+	return nf.limitHits(selectHits(hits, nf.mode, nf.seqOf))
+}
+
+// matchHits 返回按匹配模式选取并封顶后的命中
+func (nf *normalizedFilter) matchHits(text string) []hit {
+	hits, _ := nf.pickHits(nf.scan(text))
+	return hits
+}
@@ -0,0 +1,179 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const matchModeText = "abcd xyzw aaa"
+
+var matchModeWords = []string{"ab", "bc", "abcd", "cd", "xyzw", "yz", "aa"}
+
+// matchModeCases 各匹配模式下 matchModeText 的预期结果
+var matchModeCases = []struct {
+	mode    MatchMode
+	all     []string
+	count   map[string]int
+	replace string
+	remove  string
+}{
+	{MatchOverlapping, []string{"ab", "bc", "abcd", "cd", "yz", "xyzw", "aa"},
+		map[string]int{"ab": 1, "bc": 1, "abcd": 1, "cd": 1, "yz": 1, "xyzw": 1, "aa": 2}, "**** **** ***", "  "},
+	{MatchLeftmostLongest, []string{"abcd", "xyzw", "aa"},
+		map[string]int{"abcd": 1, "xyzw": 1, "aa": 1}, "**** **** **a", "  a"},
+	{MatchLeftmostFirst, []string{"ab", "cd", "xyzw", "aa"},
+		map[string]int{"ab": 1, "cd": 1, "xyzw": 1, "aa": 1}, "**** **** **a", "  a"},
+	{MatchNonOverlapping, []string{"ab", "cd", "yz", "aa"},
+		map[string]int{"ab": 1, "cd": 1, "yz": 1, "aa": 1}, "**** x**w **a", " xw a"},
+}
+
+func TestMatchMode(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		for _, c := range matchModeCases {
+			c := c
+			t.Run(c.mode.String(), func(t *testing.T) {
+				m := newTestManager(t, FilterOption{Type: ft, MatchMode: c.mode}, matchModeWords...)
+				ctx := context.Background()
+				text := matchModeText
+
+				if got := m.FindAll(text); !reflect.DeepEqual(got, c.all) {
+					t.Fatalf("FindAll: %v", got)
+				}
+				if got, _ := m.FindAllContext(ctx, text); !reflect.DeepEqual(got, c.all) {
+					t.Fatalf("FindAllContext: %v", got)
+				}
+				var bytesAll []string
+				for _, b := range m.FindAllBytes([]byte(text)) {
+					bytesAll = append(bytesAll, string(b))
+				}
+				if !reflect.DeepEqual(bytesAll, c.all) {
+					t.Fatalf("FindAllBytes: %v", bytesAll)
+				}
+				if got := m.FindAllCount(text); !reflect.DeepEqual(got, c.count) {
+					t.Fatalf("FindAllCount: %v", got)
+				}
+				if got, _ := m.FindAllCountContext(ctx, text); !reflect.DeepEqual(got, c.count) {
+					t.Fatalf("FindAllCountContext: %v", got)
+				}
+				if got := m.Replace(text, '*'); got != c.replace {
+					t.Fatalf("Replace: %q", got)
+				}
+				if got, _ := m.ReplaceContext(ctx, text, '*'); got != c.replace {
+					t.Fatalf("ReplaceContext: %q", got)
+				}
+				if got := string(m.AppendReplace(nil, []byte(text), '*')); got != c.replace {
+					t.Fatalf("AppendReplace: %q", got)
+				}
+				if got := m.Remove(text); got != c.remove {
+					t.Fatalf("Remove: %q", got)
+				}
+				if got, _ := m.RemoveContext(ctx, text); got != c.remove {
+					t.Fatalf("RemoveContext: %q", got)
+				}
+			})
+		}
+	})
+}
+
+func TestMatchMode_SnapshotAndTenant(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		for _, c := range matchModeCases {
+			c := c
+			t.Run(c.mode.String(), func(t *testing.T) {
+				m := newTestManager(t, FilterOption{Type: ft, MatchMode: c.mode}, matchModeWords...)
+				// 快照与租户沿用基础词库的匹配模式
+				if got := m.Snapshot().FindAll(matchModeText); !reflect.DeepEqual(got, c.all) {
+					t.Fatalf("snapshot FindAll: %v", got)
+				}
+				if got := m.Tenant("t1").Replace(matchModeText, '*'); got != c.replace {
+					t.Fatalf("tenant Replace: %q", got)
+				}
+			})
+		}
+	})
+}
+
+func TestMatchMode_LimitsAfterSelection(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft, MatchMode: MatchLeftmostLongest}, matchModeWords...)
+		m.SetLimits(Limits{MaxMatches: 2})
+
+		// 先选取再封顶：封顶的是选取后的命中，而不是原始命中
+		text := matchModeText
+		want := []string{"abcd", "xyzw"}
+		if got := m.FindAll(text); !reflect.DeepEqual(got, want) {
+			t.Fatalf("FindAll: %v", got)
+		}
+		var bytesAll []string
+		for _, b := range m.FindAllBytes([]byte(text)) {
+			bytesAll = append(bytesAll, string(b))
+		}
+		if !reflect.DeepEqual(bytesAll, want) {
+			t.Fatalf("FindAllBytes: %v", bytesAll)
+		}
+		got, err := m.FindAllContext(context.Background(), text)
+		var limitErr *LimitError
+		if !reflect.DeepEqual(got, want) || !errors.As(err, &limitErr) || limitErr.Actual != 3 {
+			t.Fatalf("FindAllContext: %v, %v", got, err)
+		}
+		if got := m.Replace(text, '*'); got != "**** **** aaa" {
+			t.Fatalf("Replace: %q", got)
+		}
+	})
+}
+
+func TestMatchMode_EveryOccurrence(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "sb")
+		// 默认模式下同一个词的每次出现都会被替换与计数
+		if got := m.Replace("sb 和 SB", '*'); got != "** 和 **" {
+			t.Fatalf("expect all occurrences replaced, got %q", got)
+		}
+		if got := m.FindAllCount("sb 和 SB"); got["sb"] != 2 {
+			t.Fatalf("expect count 2, got %v", got)
+		}
+	})
+}
+
+func TestMatchMode_LeftmostFirst(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft, MatchMode: MatchLeftmostFirst})
+		// 同一起点按加入词库的顺序取舍：ab 先于 abc，xyz 先于 xy
+		for _, word := range []string{"ab", "abc", "xyz", "xy"} {
+			if err := m.AddWord(word); err != nil {
+				t.Fatal(err)
+			}
+		}
+		waitForListener(t, m)
+		if got := m.FindAll("abc xyz"); !reflect.DeepEqual(got, []string{"ab", "xyz"}) {
+			t.Fatalf("FindAll: %v", got)
+		}
+		if got := m.Replace("abc xyz", '*'); got != "**c ***" {
+			t.Fatalf("Replace: %q", got)
+		}
+		var scanned []string
+		if ft != FilterDfa {
+			sc := m.NewScanner(strings.NewReader("abc xyz"))
+			for sc.Scan() {
+				scanned = append(scanned, sc.Match().Word)
+			}
+			if !reflect.DeepEqual(scanned, []string{"ab", "xyz"}) {
+				t.Fatalf("Scanner: %v", scanned)
+			}
+		}
+		// 重新加入的词排到最后
+		if err := m.DelWord("ab"); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.AddWord("ab"); err != nil {
+			t.Fatal(err)
+		}
+		waitForListener(t, m)
+		if got := m.FindAll("abc"); !reflect.DeepEqual(got, []string{"abc"}) {
+			t.Fatalf("FindAll after re-adding ab: %v", got)
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package go_sensitive_word
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// MergePolicy 合并两个词库时，同一个词两侧信息不一致的处理策略
+type MergePolicy int
+
+const (
+	MergeUnion       MergePolicy = iota // 取并集：来源合并，元数据合并（同名键保留本方的值）
+	MergePreferLeft                     // 以本方为准：已存在的词保持不变，仅新增词
+	MergePreferRight                    // 以对方为准：已存在的词使用对方的来源与元数据
+)
+
+// MergeOptions 合并选项
+type MergeOptions struct {
+	Policy       MergePolicy // 冲突处理策略，默认 MergeUnion
+	SourcePrefix string      // 为对方的来源添加前缀（如 "other:"），便于区分来源
+}
+
+// MergeConflict 两侧都存在、但来源或元数据不同的词
+type MergeConflict struct {
+	Word  string    // 归一化后的词
+	Left  DictEntry // 本方词条
+	Right DictEntry // 对方词条（已应用来源前缀）
+}
+
+// MergeReport 合并结果报告
+type MergeReport struct {
+	Added        []string        // 新增的词（按字典序）
+	Updated      []string        // 来源或元数据发生变化的已有词（按字典序）
+	Conflicts    []MergeConflict // 冲突的词（按字典序）
+	Renormalized bool            // 两侧归一化配置不同，对方的词已按本方配置重新归一化
+}
+
+// MergeWith 从另一个 Manager 合并词库，保留来源、元数据与原始写法
+// 两侧归一化配置不同时，对方的词会按本方配置从原始写法重新归一化，避免合并后无法匹配
+// 读取本方词条与写入合并结果在词库锁内一次完成，期间的其他修改不会被覆盖
+func (m *Manager) MergeWith(other *Manager, opts MergeOptions) (*MergeReport, error) {
+	if m.Store == nil || other == nil || other.Store == nil {
+		return nil, errors.New("invalid store")
+	}
+	cfg := m.Normalizer()
+	renormalize := !reflect.DeepEqual(cfg, other.Normalizer())
+	rights, order := incomingEntries(other.Store.GetEntries(), cfg, renormalize, opts.SourcePrefix)
+
+	var report *MergeReport
+	err := m.Store.UpdateEntries(func(lookup func(word string) (DictEntry, bool)) ([]DictEntry, error) {
+		var changes []DictEntry
+		report, changes = mergeEntries(rights, order, lookup, opts.Policy)
+		report.Renormalized = renormalize
+		return changes, nil
+	})
+	return report, err
+}
+
+// incomingEntries 整理对方的词条（应用来源前缀），合并归一化后相同的词，返回词条与首次出现的顺序
+// renormalize 为 true 时按 cfg 从原始写法重新归一化：同一词条的不同写法可能得到不同的词，按新词拆分；
+// 别名组的规范词按其规范写法重新归一化
+func incomingEntries(entries []DictEntry, cfg NormalizerConfig, renormalize bool, prefix string) (map[string]*DictEntry, []string) {
+	canonicalText := make(map[string]string)
+	if renormalize {
+		for _, entry := range entries {
+			if originals := entryOriginals(entry); len(originals) > 0 {
+				canonicalText[entry.Word] = originals[0].Text
+			}
+		}
+	}
+
+	rights := make(map[string]*DictEntry, len(entries))
+	order := make([]string, 0, len(entries))
+	add := func(word string, sources []string, metadata map[string]string, originals []DictOriginal, canonical string) {
+		if canonical == word {
+			canonical = ""
+		}
+		sources = prefixSources(sources, prefix)
+		originals = prefixOriginals(originals, prefix)
+		if right, ok := rights[word]; ok {
+			right.Sources = unionStrings(right.Sources, sources)
+			right.Metadata = mergeMetadata(right.Metadata, metadata)
+			right.Originals = mergeOriginals(right.Originals, originals)
+			if right.Canonical == "" {
+				right.Canonical = canonical
+			}
+			return
+		}
+		rights[word] = &DictEntry{Word: word, Sources: sources, Metadata: mergeMetadata(nil, metadata), Originals: originals, Canonical: canonical}
+		order = append(order, word)
+	}
+
+	for _, entry := range entries {
+		originals := entryOriginals(entry)
+		if !renormalize {
+			if entry.Word != "" {
+				add(entry.Word, entry.Sources, entry.Metadata, originals, entry.Canonical)
+			}
+			continue
+		}
+		canonical := entry.Canonical
+		if canonical != "" {
+			if text, ok := canonicalText[canonical]; ok {
+				canonical = text
+			}
+			canonical = NormalizeWord(canonical, cfg)
+		}
+		// 按本方配置归一化每个原始写法，得到的词可能不止一个
+		words, groups := renormalizeOriginals(originals, cfg)
+		for _, word := range words {
+			add(word, splitSources(entry.Sources, words, groups[word]), entry.Metadata, groups[word], canonical)
+		}
+	}
+	return rights, order
+}
+
+// mergeEntries 按冲突策略将对方的词条合并到本方（lookup 读取本方词条），返回合并报告与需要写入的词条
+func mergeEntries(rights map[string]*DictEntry, order []string, lookup func(word string) (DictEntry, bool), policy MergePolicy) (*MergeReport, []DictEntry) {
+	report := &MergeReport{}
+	changes := make([]DictEntry, 0, len(order))
+	for _, word := range order {
+		right := *rights[word]
+		left, exists := lookup(word)
+		if !exists {
+			report.Added = append(report.Added, word)
+			changes = append(changes, right)
+			continue
+		}
+		left.Originals = entryOriginals(left)
+		if sameEntry(left, right) {
+			// 来源与元数据一致时，仍补充对方独有的原始写法与别名组
+			originals := mergeOriginals(left.Originals, right.Originals)
+			if policy != MergePreferLeft && (!sameOriginals(left.Originals, originals) || left.Canonical == "" && right.Canonical != "") {
+				left.Originals = originals
+				if left.Canonical == "" {
+					left.Canonical = right.Canonical
+				}
+				report.Updated = append(report.Updated, word)
+				changes = append(changes, left)
+			}
+			continue
+		}
+		report.Conflicts = append(report.Conflicts, MergeConflict{Word: word, Left: left, Right: right})
+
+		var merged DictEntry
+		switch policy {
+		case MergePreferLeft:
+			continue
+		case MergePreferRight:
+			merged = right
+		default:
+			merged = DictEntry{
+				Word:      word,
+				Sources:   unionStrings(left.Sources, right.Sources),
+				Metadata:  mergeMetadata(right.Metadata, left.Metadata),
+				Originals: mergeOriginals(left.Originals, right.Originals),
+				Canonical: left.Canonical,
+			}
+			if merged.Canonical == "" {
+				merged.Canonical = right.Canonical
+			}
+		}
+		if !sameEntry(left, merged) || !sameOriginals(left.Originals, merged.Originals) || left.Canonical != merged.Canonical {
+			report.Updated = append(report.Updated, word)
+			changes = append(changes, merged)
+		}
+	}
+
+	sort.Strings(report.Added)
+	sort.Strings(report.Updated)
+	sort.Slice(report.Conflicts, func(i, j int) bool { return report.Conflicts[i].Word < report.Conflicts[j].Word })
+	return report, changes
+}
+
+// sameEntry 判断两个词条的来源（忽略顺序）与元数据是否相同
+func sameEntry(a, b DictEntry) bool {
+	if len(a.Sources) != len(b.Sources) || len(a.Metadata) != len(b.Metadata) {
+		return false
+	}
+	if len(unionStrings(a.Sources, b.Sources)) != len(a.Sources) {
+		return false
+	}
+	for k, v := range a.Metadata {
+		if bv, ok := b.Metadata[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// sameOriginals 判断两组原始写法（含顺序与来源）是否相同
+func sameOriginals(a, b []DictOriginal) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Text != b[i].Text || len(unionStrings(a[i].Sources, b[i].Sources)) != len(a[i].Sources) || len(a[i].Sources) != len(b[i].Sources) {
+			return false
+		}
+	}
+	return true
+}
+
+// prefixOriginals 为原始写法的来源添加前缀
+func prefixOriginals(originals []DictOriginal, prefix string) []DictOriginal {
+	if prefix == "" {
+		return originals
+	}
+	res := make([]DictOriginal, 0, len(originals))
+	for _, o := range originals {
+		res = append(res, DictOriginal{Text: o.Text, Sources: prefixSources(o.Sources, prefix)})
+	}
+	return res
+}
+
+// prefixSources 为来源添加前缀
+func prefixSources(sources []string, prefix string) []string {
+	if len(sources) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(sources))
+	for _, s := range sources {
+		res = append(res, prefix+s)
+	}
+	return res
+}
+
+// unionStrings 合并两个字符串列表并去重，保持首次出现的顺序
+func unionStrings(a, b []string) []string {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(a)+len(b))
+	res := make([]string, 0, len(a)+len(b))
+	for _, list := range [][]string{a, b} {
+		for _, s := range list {
+			if _, ok := seen[s]; ok {
+				continue
+			}
+			seen[s] = struct{}{}
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// mergeMetadata 合并元数据，同名键以 override 为准
+func mergeMetadata(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		res[k] = v
+	}
+	for k, v := range override {
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,107 @@
+package go_sensitive_word
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newMergeManagers(t *testing.T) (*Manager, *Manager) {
+	t.Helper()
+	left := newTestManager(t, FilterOption{Type: FilterAC})
+	right := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := left.AddEntries([]DictEntry{{Word: "共有词", Sources: []string{"left"}, Metadata: map[string]string{"level": "low"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := right.AddEntries([]DictEntry{
+		{Word: "共有词", Sources: []string{"right"}, Metadata: map[string]string{"level": "high", "note": "x"}},
+		{Word: "新词", Sources: []string{"right"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return left, right
+}
+
+func TestMergeWith_Policies(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    MergeOptions
+		sources []string
+		meta    map[string]string
+		updated []string
+	}{
+		{"union", MergeOptions{Policy: MergeUnion}, []string{"left", "right"}, map[string]string{"level": "low", "note": "x"}, []string{"共有词"}},
+		{"prefer-left", MergeOptions{Policy: MergePreferLeft}, []string{"left"}, map[string]string{"level": "low"}, nil},
+		{"prefer-right", MergeOptions{Policy: MergePreferRight}, []string{"right"}, map[string]string{"level": "high", "note": "x"}, []string{"共有词"}},
+		{"source-prefix", MergeOptions{Policy: MergeUnion, SourcePrefix: "b:"}, []string{"left", "b:right"}, map[string]string{"level": "low", "note": "x"}, []string{"共有词"}},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			left, right := newMergeManagers(t)
+			report, err := left.MergeWith(right, c.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(report.Added, []string{"新词"}) || len(report.Conflicts) != 1 || !reflect.DeepEqual(report.Updated, c.updated) {
+				t.Fatalf("unexpected report %+v", report)
+			}
+			entries := left.GetEntries()
+			var shared DictEntry
+			for _, e := range entries {
+				if e.Word == "共有词" {
+					shared = e
+				}
+			}
+			if !reflect.DeepEqual(shared.Sources, c.sources) || !reflect.DeepEqual(shared.Metadata, c.meta) {
+				t.Fatalf("unexpected merged entry %+v", shared)
+			}
+			if got := left.GetWordSources("新词"); !reflect.DeepEqual(got, []string{c.opts.SourcePrefix + "right"}) {
+				t.Fatalf("new word lost its source: %v", got)
+			}
+		})
+	}
+}
+
+func TestMergeWith_Renormalize(t *testing.T) {
+	left, right := newMergeManagers(t)
+	caseSensitive := DefaultNormalizer()
+	caseSensitive.IgnoreCase = false
+	right.nf.cfg.Store(&caseSensitive)
+	if err := right.AddWordsWithSource([]string{"BadWord"}, "right"); err != nil {
+		t.Fatal(err)
+	}
+	report, err := left.MergeWith(right, MergeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Renormalized {
+		t.Fatal("expect renormalized report")
+	}
+	if got := left.GetWordSources("badword"); !reflect.DeepEqual(got, []string{"right"}) {
+		t.Fatalf("expect renormalized word with source, got %v", got)
+	}
+}
+
+func TestMergeWith_RenormalizeFromOriginals(t *testing.T) {
+	left, right := newMergeManagers(t)
+	caseSensitive := DefaultNormalizer()
+	caseSensitive.IgnoreCase = false
+	left.nf.cfg.Store(&caseSensitive)
+	// 对方忽略大小写：两种写法归一化为同一个词 "sex"
+	if err := right.AddWordsWithSource([]string{"SEX"}, "upper"); err != nil {
+		t.Fatal(err)
+	}
+	if err := right.AddWordsWithSource([]string{"sex"}, "lower"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := left.MergeWith(right, MergeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// 按本方配置从原始写法重新归一化，拆分为两个词
+	if got := left.GetWordSources("SEX"); !reflect.DeepEqual(got, []string{"upper"}) {
+		t.Fatalf("SEX sources: %v", got)
+	}
+	if got := left.GetWordSources("sex"); !reflect.DeepEqual(got, []string{"lower"}) {
+		t.Fatalf("sex sources: %v", got)
+	}
+}
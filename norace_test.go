@@ -0,0 +1,5 @@
+//go:build !race
+
+package go_sensitive_word
+
+const raceEnabled = false
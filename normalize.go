@@ -6,16 +6,30 @@ import (
 
 // NormalizerConfig 定义文本归一化的策略
 type NormalizerConfig struct {
-	IgnoreCase         bool          // 忽略大小写（转小写）
-	ToHalfWidth        bool          // 全角转半角
-	IgnoreRepeat       bool          // 忽略连续重复字符（将连续相同字符压缩为 1 个）
-	IgnoreDigitType    bool          // 归一化各种数字写法为阿拉伯数字
-	IgnoreSimpTrad     bool          // 繁简归一（繁体字转简体）
-	IgnoreEnglishStyle bool          // 归一化英文变体（花体、数学字母等）
-	RemoveZeroWidth    bool          // 剔除零宽字符（防止绕过）
-	HomoglyphMap       map[rune]rune // 同形字映射表（防止混淆字符绕过）
+	IgnoreCase         bool          `json:"ignore_case"`             // 忽略大小写（转小写）
+	ToHalfWidth        bool          `json:"to_half_width"`           // 全角转半角
+	IgnoreRepeat       bool          `json:"ignore_repeat"`           // 忽略连续重复字符（将连续相同字符压缩为 1 个）
+	IgnoreDigitType    bool          `json:"ignore_digit_type"`       // 归一化各种数字写法为阿拉伯数字
+	IgnoreSimpTrad     bool          `json:"ignore_simp_trad"`        // 繁简归一（繁体字转简体）
+	IgnoreEnglishStyle bool          `json:"ignore_english_style"`    // 归一化英文变体（花体、数学字母等）
+	RemoveZeroWidth    bool          `json:"remove_zero_width"`       // 剔除零宽字符（防止绕过）
+	HomoglyphMap       map[rune]rune `json:"homoglyph_map,omitempty"` // 同形字映射表（防止混淆字符绕过）
+	SkipChars          SkipClass     `json:"skip_chars,omitempty"`    // 匹配时跳过的干扰字符类别（如 "法@轮#功"、"f.u.c.k"）
+	SkipRunes          string        `json:"skip_runes,omitempty"`    // 额外跳过的干扰字符
+	MaxSkipGap         int           `json:"max_skip_gap,omitempty"`  // 词内相邻两个字符之间最多跳过的干扰字符数（<= 0 表示不限制，零宽字符不计数）
 }
 
+// SkipClass 干扰字符类别，可组合使用
+type SkipClass uint8
+
+const (
+	SkipPunct  = SkipClass(normalize.SkipPunct)  // 标点
+	SkipSymbol = SkipClass(normalize.SkipSymbol) // 符号（数学、货币、修饰符号等）
+	SkipSpace  = SkipClass(normalize.SkipSpace)  // 空白
+	SkipEmoji  = SkipClass(normalize.SkipEmoji)  // emoji（含变体选择符、零宽连接符与肤色修饰符）
+	SkipNoise  = SkipPunct | SkipSymbol | SkipSpace | SkipEmoji
+)
+
 // toInternalConfig 将公开配置转换为内部配置
 func (c NormalizerConfig) toInternalConfig() normalize.Config {
 	return normalize.Config{
@@ -27,6 +41,8 @@ func (c NormalizerConfig) toInternalConfig() normalize.Config {
 		IgnoreEnglishStyle: c.IgnoreEnglishStyle,
 		RemoveZeroWidth:    c.RemoveZeroWidth,
 		HomoglyphMap:       c.HomoglyphMap,
+		Skip:               uint8(c.SkipChars),
+		SkipRunes:          c.SkipRunes,
 	}
 }
 
@@ -57,6 +73,28 @@ func StrictNormalizer() NormalizerConfig {
 	}
 }
 
+// skipGapOK 判断命中的原文片段中连续干扰字符的个数是否都不超过 MaxSkipGap
+// 片段首尾均为词中的字符，其中的干扰字符都位于词内相邻两个字符之间
+// 只统计 SkipChars、SkipRunes 跳过的字符；剔除的零宽字符不计数，也不打断连续的干扰字符
+func (c NormalizerConfig) skipGapOK(orig string) bool {
+	if c.MaxSkipGap <= 0 {
+		return true
+	}
+	cfg := c.toInternalConfig()
+	run := 0
+	for _, r := range orig {
+		switch {
+		case normalize.Skippable(r, cfg):
+			if run++; run > c.MaxSkipGap {
+				return false
+			}
+		case !normalize.Ignorable(r, cfg):
+			run = 0
+		}
+	}
+	return true
+}
+
 // NormalizeTextWithMap 对文本做归一化，同时返回从规范化索引到原始索引的映射
 // 返回：规范化后的字符串、规范化索引 -> 原始索引 的映射
 func NormalizeTextWithMap(s string, cfg NormalizerConfig) (string, []int) {
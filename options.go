@@ -13,6 +13,7 @@ const (
 const (
 	FilterDfa = iota // DFA 敏感词过滤算法（默认）
 	FilterAC
+	FilterDAT // 双数组 AC 自动机：转移表存放在连续数组中，内存占用更小、匹配更快，词库变更时整体重建
 )
 
 // StoreOption 定义了词库存储的配置选项
@@ -24,7 +25,40 @@ type StoreOption struct {
 // FilterOption 定义了敏感词过滤器的配置选项
 // Type 字段用于指定过滤算法的实现方式，如 DFA、Trie、正则等。
 type FilterOption struct {
-	Type uint32 // 过滤器类型标识，例如 FilterDfa
+	Type      uint32          // 过滤器类型标识，例如 FilterDfa
+	Prefilter bool            // 启用预过滤器（词首字符位图 + 双字符前缀布隆过滤器），快速放行无敏感词的文本
+	Parallel  ParallelOptions // 超长文本分块并发匹配（仅 FilterAC、FilterDAT 支持）
+	MatchMode MatchMode       // 命中选取方式（默认 MatchOverlapping，保留全部命中）
+	// WordBoundary 全局词边界规则（默认 BoundaryNone 子串匹配），词条元数据 MetaBoundary 可按词覆盖
+	WordBoundary BoundaryMode
+	// Normalizer 归一化配置（nil 时使用 DefaultNormalizer），如开启干扰字符跳过
+	Normalizer *NormalizerConfig
+}
+
+// DefaultParallelMinChunk 并发匹配时每块的默认最少字符数
+const DefaultParallelMinChunk = 1 << 16
+
+// ParallelOptions 超长文本分块并发匹配配置
+// 规范化文本按块切分后并发匹配，相邻块重叠最长词长 - 1 个字符，结果与串行匹配完全一致
+// 仅作用于 FindAll、FindAllCount、Replace、Remove 等需要全部命中的方法
+type ParallelOptions struct {
+	Workers      int // 最大并发数，<= 1 表示不启用
+	MinChunkSize int // 每块最少字符数（规范化后），<= 0 时使用 DefaultParallelMinChunk；文本不足两块时串行匹配
+}
+
+// chunks 返回长度为 n 的文本可切分的块数（小于 2 表示串行匹配）
+func (o ParallelOptions) chunks(n int) int {
+	if o.Workers <= 1 {
+		return 0
+	}
+	return n / o.minChunk()
+}
+
+func (o ParallelOptions) minChunk() int {
+	if o.MinChunkSize <= 0 {
+		return DefaultParallelMinChunk
+	}
+	return o.MinChunkSize
 }
 
 // 内置敏感词词库（通过 go:embed 嵌入编译时）
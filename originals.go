@@ -0,0 +1,135 @@
+package go_sensitive_word
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/store"
+)
+
+// DictOriginal 词的原始写法（归一化之前）及其来源
+type DictOriginal = store.Original
+
+// entryOriginals 返回词条的原始写法，未记录时以词本身及其来源作为唯一的原始写法
+func entryOriginals(entry DictEntry) []DictOriginal {
+	if len(entry.Originals) > 0 {
+		return entry.Originals
+	}
+	word := strings.TrimSpace(entry.Word)
+	if word == "" {
+		return nil
+	}
+	return []DictOriginal{{Text: word, Sources: entry.Sources}}
+}
+
+// renormalizeOriginals 按 cfg 归一化每个原始写法，返回得到的词（保持首次出现的顺序）及各词对应的原始写法
+// 词条中的词按其所在词库的配置归一化，配置不同时需要从原始写法重新计算，一个词条可能得到多个词
+func renormalizeOriginals(originals []DictOriginal, cfg NormalizerConfig) ([]string, map[string][]DictOriginal) {
+	var words []string
+	groups := make(map[string][]DictOriginal)
+	for _, o := range originals {
+		word := NormalizeWord(o.Text, cfg)
+		if word == "" {
+			continue
+		}
+		if _, ok := groups[word]; !ok {
+			words = append(words, word)
+		}
+		groups[word] = append(groups[word], o)
+	}
+	return words, groups
+}
+
+// splitSources 返回重新归一化后某个词的来源：词条拆分为多个词时只保留以其写法添加时的来源
+func splitSources(sources []string, words []string, own []DictOriginal) []string {
+	if len(words) < 2 {
+		return sources
+	}
+	var res []string
+	for _, o := range own {
+		res = unionStrings(res, o.Sources)
+	}
+	if len(res) == 0 {
+		return sources
+	}
+	return res
+}
+
+// mergeOriginals 按写法合并两组原始写法（来源取并集），保持首次出现的顺序
+func mergeOriginals(a, b []DictOriginal) []DictOriginal {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	res := make([]DictOriginal, 0, len(a)+len(b))
+	index := make(map[string]int, len(a)+len(b))
+	for _, list := range [][]DictOriginal{a, b} {
+		for _, o := range list {
+			if i, ok := index[o.Text]; ok {
+				res[i].Sources = unionStrings(res[i].Sources, o.Sources)
+				continue
+			}
+			index[o.Text] = len(res)
+			res = append(res, DictOriginal{Text: o.Text, Sources: unionStrings(nil, o.Sources)})
+		}
+	}
+	return res
+}
+
+// rawEntries 将原始写法转换为归一化后的词条（记录原始写法，可选来源）
+func (m *Manager) rawEntries(words []string, source string) []DictEntry {
+	cfg := m.Normalizer()
+	var sources []string
+	if source != "" {
+		sources = []string{source}
+	}
+	entries := make([]DictEntry, 0, len(words))
+	for _, word := range words {
+		raw := strings.TrimSpace(word)
+		normalized := NormalizeWord(raw, cfg)
+		if normalized == "" {
+			continue
+		}
+		entries = append(entries, DictEntry{
+			Word:      normalized,
+			Sources:   sources,
+			Originals: []DictOriginal{{Text: raw, Sources: sources}},
+		})
+	}
+	return entries
+}
+
+// GetOriginals 获取词的全部原始写法（首个为规范写法）
+// word 可以是任意写法，会先按 Manager 的归一化配置归一化
+func (m *Manager) GetOriginals(word string) []DictOriginal {
+	if m.Store == nil {
+		return nil
+	}
+	return m.Store.GetOriginals(NormalizeWord(word, m.Normalizer()))
+}
+
+// CanonicalOriginal 获取词的规范原始写法（首次添加时的写法），未记录时返回归一化后的词
+func (m *Manager) CanonicalOriginal(word string) string {
+	normalized := NormalizeWord(word, m.Normalizer())
+	if m.Store == nil || normalized == "" {
+		return normalized
+	}
+	if originals := m.Store.GetOriginals(normalized); len(originals) > 0 {
+		return originals[0].Text
+	}
+	return normalized
+}
+
+// GetAllOriginalWordSources 获取所有词的来源映射，键为规范原始写法
+// 与 GetAllWordSources 相同，但便于管理界面展示词库维护者录入的写法
+func (m *Manager) GetAllOriginalWordSources() (map[string][]string, error) {
+	if m.Store == nil {
+		return nil, errors.New("store is nil")
+	}
+	entries := m.Store.GetEntries()
+	result := make(map[string][]string, len(entries))
+	for _, entry := range entries {
+		originals := entryOriginals(entry)
+		result[originals[0].Text] = entry.Sources
+	}
+	return result, nil
+}
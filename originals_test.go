@@ -0,0 +1,64 @@
+package go_sensitive_word
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// newOriginalsManager 以两种原始写法添加同一个词
+func newOriginalsManager(t *testing.T) *Manager {
+	t.Helper()
+	m := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := m.AddWordsWithSource([]string{"ＦＵＣＫ"}, "en"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddWordsWithSource([]string{"Fuck"}, "custom"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+var testOriginals = []DictOriginal{{Text: "ＦＵＣＫ", Sources: []string{"en"}}, {Text: "Fuck", Sources: []string{"custom"}}}
+
+func TestOriginals(t *testing.T) {
+	m := newOriginalsManager(t)
+	if got := m.GetOriginals("fuck"); !reflect.DeepEqual(got, testOriginals) {
+		t.Fatalf("originals: got %v, want %v", got, testOriginals)
+	}
+	if got := m.CanonicalOriginal("FUCK"); got != "ＦＵＣＫ" {
+		t.Fatalf("canonical: got %q", got)
+	}
+	all, err := m.GetAllOriginalWordSources()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(all, map[string][]string{"ＦＵＣＫ": {"en", "custom"}}) {
+		t.Fatalf("all original sources: %v", all)
+	}
+}
+
+func TestOriginals_MatchResult(t *testing.T) {
+	m := newOriginalsManager(t)
+	syncTestFilter(t, m)
+	results := m.FindAllWithSource("what the fuck")
+	if len(results) != 1 || results[0].Original != "ＦＵＣＫ" {
+		t.Fatalf("match results: %+v", results)
+	}
+}
+
+func TestOriginals_ExportImport(t *testing.T) {
+	m := newOriginalsManager(t)
+	// 原始写法经 CSV 导出导入后保留
+	var buf bytes.Buffer
+	if err := m.Export(&buf, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	dst := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := dst.Import(&buf, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	if got := dst.GetOriginals("fuck"); !reflect.DeepEqual(got, testOriginals) {
+		t.Fatalf("imported originals: got %v, want %v", got, testOriginals)
+	}
+}
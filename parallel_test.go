@@ -0,0 +1,57 @@
+package go_sensitive_word
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+func TestParallelMatch(t *testing.T) {
+	words := []string{"赌博", "博彩", "台湾国", "aba", "ab", "b", "长长长长长长长长长长长长词"}
+	alphabet := []string{"赌", "博", "彩", "台", "湾", "国", "a", "b", "长", "词", "，", "Ａ", " "}
+	rng := rand.New(rand.NewSource(1))
+	var texts []string
+	for _, n := range []int{0, 1, 5, 17, 64, 333, 4000} {
+		var sb strings.Builder
+		for i := 0; i < n; i++ {
+			sb.WriteString(alphabet[rng.Intn(len(alphabet))])
+		}
+		texts = append(texts, sb.String())
+	}
+	texts = append(texts, strings.Repeat("长", 100)+"词", strings.Repeat("aba", 500))
+
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		plain := newTestManager(t, FilterOption{Type: ft}, words...)
+		for _, par := range []ParallelOptions{{Workers: 4, MinChunkSize: 3}, {Workers: 16, MinChunkSize: 1}, {Workers: 3, MinChunkSize: 100}} {
+			par := par
+			t.Run(fmt.Sprintf("workers=%d,chunk=%d", par.Workers, par.MinChunkSize), func(t *testing.T) {
+				m := newTestManager(t, FilterOption{Type: ft, Parallel: par}, words...)
+				pr := m.nf.inner.(filter.ParallelRangedFilter)
+				rf := m.nf.inner.(filter.RangedFilter)
+				for _, text := range texts {
+					norm, _ := NormalizeTextWithMap(text, m.Normalizer())
+					got := pr.FindAllRangesParallel([]rune(norm), par.Workers, par.MinChunkSize)
+					if want := rf.FindAllRanges(norm); !reflect.DeepEqual(got, want) && len(got)+len(want) > 0 {
+						t.Fatalf("ranges of %d runes differ: %d vs %d", len([]rune(norm)), len(got), len(want))
+					}
+					if got, want := m.FindAll(text), plain.FindAll(text); !reflect.DeepEqual(got, want) {
+						t.Fatalf("find all: %v, want %v", got, want)
+					}
+					if got, want := m.FindAllCount(text), plain.FindAllCount(text); !reflect.DeepEqual(got, want) {
+						t.Fatalf("find all count: %v, want %v", got, want)
+					}
+					if got, want := m.Replace(text, '*'), plain.Replace(text, '*'); got != want {
+						t.Fatalf("replace: %q, want %q", got, want)
+					}
+					if got, want := m.Remove(text), plain.Remove(text); got != want {
+						t.Fatalf("remove: %q, want %q", got, want)
+					}
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,254 @@
+package go_sensitive_word
+
+import (
+	"errors"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/normalize"
+)
+
+// 预过滤器参数
+const (
+	prefilterRuneBits    = 1 << 16 // 首字符/单字符词位图大小（按字符低 16 位索引）
+	prefilterBitsPerGram = 10      // 布隆过滤器每个双字符前缀占用的位数
+	prefilterHashes      = 3       // 布隆过滤器哈希函数个数
+	prefilterMinBloom    = 1 << 10 // 布隆过滤器最小位数
+)
+
+// PrefilterStats 预过滤器统计信息
+type PrefilterStats struct {
+	Enabled           bool    // 是否启用
+	Generation        uint64  // 构建时匹配结构的版本（词库每次发布后变化）
+	FirstRunes        int     // 词首字符数
+	SingleRunes       int     // 单字符词数
+	Bigrams           int     // 双字符前缀数
+	BloomBits         int     // 布隆过滤器位数
+	Rejected          uint64  // 直接判定为无命中的次数（跳过自动机）
+	Passed            uint64  // 通过预过滤、进入自动机匹配的次数
+	FalsePositives    uint64  // 通过预过滤但自动机未命中的次数
+	FalsePositiveRate float64 // 误判率：FalsePositives / (FalsePositives + Rejected)，即无命中文本中未被拦下的比例
+}
+
+// prefilter 由词库构建的预过滤器：词首字符位图、单字符词位图与双字符前缀布隆过滤器
+// 任何命中都要求文本中某个位置是单字符词，或相邻两个字符是某个词的前两个字符，
+// 两者都不满足时文本一定不含敏感词（不会漏判，只可能误判）
+type prefilter struct {
+	gen     uint64
+	first   []uint64
+	single  []uint64
+	bloom   []uint64
+	mask    uint64 // 布隆过滤器位数 - 1
+	firsts  int
+	singles int
+	bigrams int
+}
+
+func prefilterHash(a, b rune) uint64 {
+	// splitmix64 终结函数
+	h := uint64(uint32(a))<<32 | uint64(uint32(b))
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
+
+func setBit(set []uint64, i uint64)      { set[i>>6] |= 1 << (i & 63) }
+func hasBit(set []uint64, i uint64) bool { return set[i>>6]&(1<<(i&63)) != 0 }
+
+// buildPrefilter 使用匹配结构中全部词的前两个字符构建预过滤器
+func buildPrefilter(src filter.PrefixSource, gen uint64) *prefilter {
+	type gram struct{ a, b rune }
+	var grams []gram
+	p := &prefilter{
+		gen:    gen,
+		first:  make([]uint64, prefilterRuneBits/64),
+		single: make([]uint64, prefilterRuneBits/64),
+	}
+	src.WalkPrefixes(func(first, second rune) {
+		idx := uint64(uint32(first)) & (prefilterRuneBits - 1)
+		if second < 0 {
+			if !hasBit(p.single, idx) {
+				p.singles++
+			}
+			setBit(p.single, idx)
+			return
+		}
+		if !hasBit(p.first, idx) {
+			p.firsts++
+		}
+		setBit(p.first, idx)
+		grams = append(grams, gram{first, second})
+	})
+	p.bigrams = len(grams)
+	size := uint64(prefilterMinBloom)
+	if n := uint64(len(grams)) * prefilterBitsPerGram; n > size {
+		size = 1 << bits.Len64(n-1)
+	}
+	p.bloom = make([]uint64, size/64)
+	p.mask = size - 1
+	for _, g := range grams {
+		h := prefilterHash(g.a, g.b)
+		h1, h2 := h, h>>32|1
+		for i := uint64(0); i < prefilterHashes; i++ {
+			setBit(p.bloom, (h1+i*h2)&p.mask)
+		}
+	}
+	return p
+}
+
+// step 检查规范化文本中相邻的两个字符，返回 true 表示此处可能有命中
+func (p *prefilter) step(prev rune, hasPrev bool, r rune) bool {
+	idx := uint64(uint32(r)) & (prefilterRuneBits - 1)
+	if hasBit(p.single, idx) {
+		return true
+	}
+	if !hasPrev || !hasBit(p.first, uint64(uint32(prev))&(prefilterRuneBits-1)) {
+		return false
+	}
+	h := prefilterHash(prev, r)
+	h1, h2 := h, h>>32|1
+	for i := uint64(0); i < prefilterHashes; i++ {
+		if !hasBit(p.bloom, (h1+i*h2)&p.mask) {
+			return false
+		}
+	}
+	return true
+}
+
+// prefilterState 归一化包装器持有的预过滤器：词库发布（匹配结构版本变化）后首次查询时重建
+type prefilterState struct {
+	src            filter.PrefixSource
+	mu             sync.Mutex // 串行化重建
+	cur            atomic.Pointer[prefilter]
+	rejected       atomic.Uint64
+	passed         atomic.Uint64
+	falsePositives atomic.Uint64
+}
+
+// load 返回与当前匹配结构版本一致的预过滤器
+func (s *prefilterState) load() *prefilter {
+	gen := s.src.Generation()
+	if p := s.cur.Load(); p != nil && p.gen == gen {
+		return p
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.cur.Load(); p != nil && p.gen == gen {
+		return p
+	}
+	p := buildPrefilter(s.src, gen)
+	s.cur.Store(p)
+	return p
+}
+
+// snapshot 为快照的自动机创建预过滤器：gen 为创建快照前的匹配结构版本，期间没有发布新版本时复用当前预过滤器
+func (s *prefilterState) snapshot(inner filter.Filter, gen uint64) *prefilterState {
+	src, ok := inner.(filter.PrefixSource)
+	if !ok {
+		return nil
+	}
+	snap := &prefilterState{src: src}
+	if p := s.load(); p.gen == gen && s.src.Generation() == gen {
+		frozen := *p
+		frozen.gen = src.Generation()
+		snap.cur.Store(&frozen)
+	}
+	return snap
+}
+
+// enablePrefilter 启用预过滤器，底层过滤器需实现 filter.PrefixSource
+func (nf *normalizedFilter) enablePrefilter() error {
+	src, ok := nf.inner.(filter.PrefixSource)
+	if !ok {
+		return errors.New("filter does not support prefilter")
+	}
+	nf.pf = &prefilterState{src: src}
+	return nil
+}
+
+// prefilterString 预过滤字符串，返回 false 表示一定没有命中（未启用时总是返回 true）
+func (nf *normalizedFilter) prefilterString(text string) bool {
+	if nf.pf == nil {
+		return true
+	}
+	p := nf.pf.load()
+	stream := normalize.NewStream(nf.config().toInternalConfig())
+	var prev rune
+	hasPrev := false
+	for _, r := range text {
+		nr, ok := stream.Next(r)
+		if !ok {
+			continue
+		}
+		if p.step(prev, hasPrev, nr) {
+			nf.pf.passed.Add(1)
+			return true
+		}
+		prev, hasPrev = nr, true
+	}
+	nf.pf.rejected.Add(1)
+	return false
+}
+
+// prefilterBytes 预过滤字节切片，语义同 prefilterString
+func (nf *normalizedFilter) prefilterBytes(src []byte) bool {
+	if nf.pf == nil {
+		return true
+	}
+	p := nf.pf.load()
+	stream := normalize.NewStream(nf.config().toInternalConfig())
+	var prev rune
+	hasPrev := false
+	for pos := 0; pos < len(src); {
+		r, size := utf8.DecodeRune(src[pos:])
+		pos += size
+		nr, ok := stream.Next(r)
+		if !ok {
+			continue
+		}
+		if p.step(prev, hasPrev, nr) {
+			nf.pf.passed.Add(1)
+			return true
+		}
+		prev, hasPrev = nr, true
+	}
+	nf.pf.rejected.Add(1)
+	return false
+}
+
+// prefilterResult 记录通过预过滤后的匹配结果（用于统计误判率）
+func (nf *normalizedFilter) prefilterResult(hit bool) {
+	if nf.pf != nil && !hit {
+		nf.pf.falsePositives.Add(1)
+	}
+}
+
+// PrefilterStats 返回预过滤器统计信息（通过 FilterOption.Prefilter 启用）
+func (m *Manager) PrefilterStats() PrefilterStats {
+	if m.nf == nil || m.nf.pf == nil {
+		return PrefilterStats{}
+	}
+	s := m.nf.pf
+	p := s.load()
+	stats := PrefilterStats{
+		Enabled:        true,
+		Generation:     p.gen,
+		FirstRunes:     p.firsts,
+		SingleRunes:    p.singles,
+		Bigrams:        p.bigrams,
+		BloomBits:      int(p.mask + 1),
+		Rejected:       s.rejected.Load(),
+		Passed:         s.passed.Load(),
+		FalsePositives: s.falsePositives.Load(),
+	}
+	if negatives := stats.FalsePositives + stats.Rejected; negatives > 0 {
+		stats.FalsePositiveRate = float64(stats.FalsePositives) / float64(negatives)
+	}
+	return stats
+}
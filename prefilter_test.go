@@ -0,0 +1,73 @@
+package go_sensitive_word
+
+import (
+	"fmt"
+	"testing"
+)
+
+var testPrefilterWords = []string{"赌博", "SEX", "毒", "台湾国"}
+
+// prefilterTexts 返回预过滤测试文本：少量含敏感词，大量正常消息
+func prefilterTexts() []string {
+	texts := []string{"今天天气不错", "他在赌博", "ＳＥＸ", "吸毒", "台湾", "赌", "", "sensitive"}
+	for i := 0; i < 200; i++ {
+		texts = append(texts, fmt.Sprintf("正常消息 %d 号", i))
+	}
+	return texts
+}
+
+func TestPrefilter(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft, Prefilter: true}, testPrefilterWords...)
+		plain := newTestManager(t, FilterOption{Type: ft}, testPrefilterWords...)
+		for _, text := range prefilterTexts() {
+			if got, want := m.IsSensitive(text), plain.IsSensitive(text); got != want {
+				t.Fatalf("is sensitive %q: %v, want %v", text, got, want)
+			}
+			if got, want := m.Replace(text, '*'), plain.Replace(text, '*'); got != want {
+				t.Fatalf("replace %q: %q, want %q", text, got, want)
+			}
+			if got, want := m.IsSensitiveBytes([]byte(text)), plain.IsSensitive(text); got != want {
+				t.Fatalf("is sensitive bytes %q: %v, want %v", text, got, want)
+			}
+		}
+	})
+}
+
+func TestPrefilter_Stats(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft, Prefilter: true}, testPrefilterWords...)
+		for _, text := range prefilterTexts() {
+			m.IsSensitive(text)
+		}
+		stats := m.PrefilterStats()
+		if !stats.Enabled || stats.Bigrams != 3 || stats.SingleRunes != 1 {
+			t.Fatalf("stats: %+v", stats)
+		}
+		if stats.Rejected == 0 || stats.FalsePositiveRate > 0.1 {
+			t.Fatalf("prefilter not effective: %+v", stats)
+		}
+	})
+}
+
+func TestPrefilter_Rebuild(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft, Prefilter: true}, testPrefilterWords...)
+		before := m.PrefilterStats()
+		// 词库增量发布后重建：等待监听协程发布
+		if err := m.AddWord("今天"); err != nil {
+			t.Fatal(err)
+		}
+		waitForSensitive(t, m, "今天天气不错")
+		if got := m.PrefilterStats(); got.Generation == before.Generation || got.Bigrams != 4 {
+			t.Fatalf("not rebuilt: %+v", got)
+		}
+	})
+}
+
+func TestPrefilter_DisabledByDefault(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, testPrefilterWords...)
+	if m.PrefilterStats().Enabled {
+		t.Fatal("prefilter should be disabled by default")
+	}
+}
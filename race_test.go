@@ -0,0 +1,6 @@
+//go:build race
+
+package go_sensitive_word
+
+// raceEnabled 竞态检测下 sync.Pool 会随机丢弃对象，分配次数断言不再可靠
+const raceEnabled = true
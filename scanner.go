@@ -0,0 +1,543 @@
+package go_sensitive_word
+
+import (
+	"errors"
+	"io"
+	"math"
+	"unicode/utf8"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/normalize"
+)
+
+// ErrStreamingUnsupported 底层过滤器不支持逐字符匹配（流式扫描仅支持 FilterAC、FilterDAT）
+var ErrStreamingUnsupported = errors.New("filter does not support streaming scan")
+
+// scanChunkSize 流式扫描每次从 io.Reader 读取的字节数
+const scanChunkSize = 32 << 10
+
+// Match 流式扫描的一次命中
+type Match struct {
+	Word  string // 命中的敏感词（归一化后）
+	Text  string // 原文片段
+	Start int64  // 原文起始字节偏移（相对输入开头）
+	End   int64  // 原文结束字节偏移（不含）
+}
+
+// winRune 最近读入的规范化字符及其原文字节区间
+type winRune struct {
+	r          rune
+	start, end int64
+	prev       rune // 前一个原文字符（-1 表示输入开头）
+}
+
+// normRange 规范化索引闭区间
+type normRange struct {
+	start, end int64
+}
+
+// coreHit 流式扫描中的一次命中
+type coreHit struct {
+	norm   normRange // 规范化索引区间
+	match  Match
+	before rune   // 命中前相邻的原文字符（-1 表示输入开头）
+	after  rune   // 命中后相邻的原文字符（-1 表示输入结尾）
+	open   bool   // 尚未读到命中后的原文字符
+	seq    uint64 // 词加入词库的序号（仅 MatchLeftmostFirst 使用）
+}
+
+// streamCore 跨分块的流式匹配状态：原文缓冲、归一化状态、自动机状态与最近的规范化字符
+// 只保留仍可能属于后续命中的原文与字符，内存占用与输入长度无关
+type streamCore struct {
+	src      io.Reader
+	matcher  filter.Matcher
+	stream   normalize.Stream
+	maxLen   int
+	allow    filter.Matcher // 白名单匹配器（nil 表示没有白名单）
+	allowLen int            // 白名单最长短语的长度
+	nf       *normalizedFilter
+	cfg      NormalizerConfig
+	bounds   bool // 需要检查词边界
+	last     rune // 最近解码的原文字符（-1 表示尚未读取）
+
+	raw     []byte // 原文缓冲，raw[0] 对应偏移 rawBase
+	rawBase int64
+	pos     int64 // 下一个待解码字节的偏移
+	retain  int64 // 调用方仍需要的最小偏移
+	srcDone bool  // io.Reader 已读完
+	done    bool  // 全部字符已处理
+	err     error
+	limit   error // 达到资源限制提前结束时的 *LimitError
+
+	mode   MatchMode
+	max    int   // MaxMatches（<=0 表示不限制）
+	input  int   // MaxInputRunes（<=0 表示不限制）
+	runes  int   // 已读取的原文字符数
+	picked int   // 已选取的命中数
+	next   int64 // 下一个可选取命中的最小规范化起始位置
+
+	n        int64       // 已产生的规范化字符数
+	window   []winRune   // 最近的规范化字符（至少保留 maxLen 个）
+	allowed  []normRange // 仍可能覆盖命中的白名单区间
+	pending  []coreHit   // 等待白名单判定的命中（按结束位置排序）
+	accepted []coreHit   // 已通过判定、等待按匹配模式选取的命中（按结束位置排序）
+	ready    []coreHit   // 已选取的命中（按结束位置排序）
+}
+
+func newStreamCore(nf *normalizedFilter, r io.Reader) (*streamCore, error) {
+	streamer, ok := nf.inner.(filter.Streamer)
+	if !ok {
+		return nil, ErrStreamingUnsupported
+	}
+	cfg := nf.config()
+	c := &streamCore{
+		src:     r,
+		matcher: streamer.NewMatcher(),
+		stream:  normalize.NewStream(cfg.toInternalConfig()),
+		cfg:     cfg,
+		retain:  math.MaxInt64,
+		nf:      nf,
+		bounds:  nf.boundaryActive(),
+		last:    -1,
+		mode:    nf.mode,
+	}
+	c.maxLen = c.matcher.MaxLen()
+	lim := nf.limits()
+	c.max, c.input = lim.MaxMatches, lim.MaxInputRunes
+	if allow := nf.allow.Load(); !allow.empty() {
+		c.allow = allow.matcher.NewMatcher()
+		c.allowLen = c.allow.MaxLen()
+	}
+	return c, nil
+}
+
+// step 处理一个字符，没有更多输入（或读取出错）时返回 false
+func (c *streamCore) step() bool {
+	if c.done {
+		return false
+	}
+	for !c.srcDone && !utf8.FullRune(c.raw[c.pos-c.rawBase:]) {
+		c.fill()
+	}
+	buf := c.raw[c.pos-c.rawBase:]
+	// 超出 MaxInputRunes 时只检测已读取的前缀，其余原文不再处理
+	truncated := len(buf) > 0 && c.input > 0 && c.runes == c.input
+	if len(buf) == 0 || truncated {
+		c.resolve(true)
+		if truncated && c.limit == nil {
+			c.limit = &LimitError{Kind: LimitInputRunes, Max: c.input, Actual: c.input + 1}
+		}
+		c.done = true
+		return false
+	}
+	c.runes++
+	r, size := utf8.DecodeRune(buf)
+	start := c.pos
+	c.pos += int64(size)
+	prev := c.last
+	c.last = r
+	for i := len(c.pending) - 1; i >= 0 && c.pending[i].open; i-- {
+		c.pending[i].after, c.pending[i].open = r, false
+	}
+	nr, ok := c.stream.Next(r)
+	if !ok {
+		c.resolve(false)
+		return true
+	}
+	k := c.n
+	c.n++
+	if len(c.window) > 2*c.maxLen+64 {
+		c.window = append(c.window[:0], c.window[len(c.window)-c.maxLen:]...)
+	}
+	c.window = append(c.window, winRune{r: nr, start: start, end: c.pos, prev: prev})
+
+	c.matcher.Step(nr)
+	for i := 0; ; i++ {
+		l := c.matcher.Output(i)
+		if l == 0 || l > len(c.window) {
+			break
+		}
+		w := c.window[len(c.window)-l:]
+		word := make([]rune, l)
+		for j := range w {
+			word[j] = w[j].r
+		}
+		c.pending = append(c.pending, coreHit{
+			norm: normRange{start: k - int64(l) + 1, end: k},
+			match: Match{
+				Word:  string(word),
+				Text:  string(c.raw[w[0].start-c.rawBase : c.pos-c.rawBase]),
+				Start: w[0].start,
+				End:   c.pos,
+			},
+			before: w[0].prev,
+			after:  -1,
+			open:   c.bounds,
+		})
+	}
+	if c.allow != nil {
+		c.allow.Step(nr)
+		for i := 0; ; i++ {
+			l := c.allow.Output(i)
+			if l == 0 {
+				break
+			}
+			c.allowed = append(c.allowed, normRange{start: k - int64(l) + 1, end: k})
+		}
+	}
+	c.resolve(false)
+	return true
+}
+
+// resolve 确认不会再被白名单覆盖的命中，final 为 true 时确认全部命中
+func (c *streamCore) resolve(final bool) {
+	for len(c.pending) > 0 {
+		h := c.pending[0]
+		// 覆盖该命中的白名单区间起始不晚于命中起始，最晚在 start+allowLen-1 处结束
+		if !final && c.allow != nil && c.n-1 < h.norm.start+int64(c.allowLen)-1 {
+			break
+		}
+		// 词边界需要命中后的原文字符
+		if !final && h.open {
+			break
+		}
+		c.pending = c.pending[1:]
+		if !c.cfg.skipGapOK(h.match.Text) || c.bounds && !c.nf.bounded(h.match.Word, h.before, h.after) {
+			continue
+		}
+		if !c.covered(h.norm) {
+			if c.mode == MatchLeftmostFirst {
+				h.seq = c.nf.seqOf(h.match.Word)
+			}
+			c.accepted = append(c.accepted, h)
+		}
+	}
+	c.pick(final)
+	if len(c.allowed) == 0 {
+		return
+	}
+	// 结束位置早于全部待定命中与后续命中起始位置的白名单区间不再有用
+	min := c.n - int64(c.maxLen)
+	for _, h := range c.pending {
+		if h.norm.start < min {
+			min = h.norm.start
+		}
+	}
+	keep := c.allowed[:0]
+	for _, a := range c.allowed {
+		if a.end >= min {
+			keep = append(keep, a)
+		}
+	}
+	c.allowed = keep
+}
+
+// pick 按匹配模式选取命中，final 为 true 时不会再有新的命中
+func (c *streamCore) pick(final bool) {
+	if c.mode == MatchOverlapping {
+		for _, h := range c.accepted {
+			if c.limit != nil {
+				break
+			}
+			c.emit(h)
+		}
+		c.accepted = c.accepted[:0]
+		return
+	}
+	for c.limit == nil {
+		// 与已选命中重叠的命中不再可选
+		keep := c.accepted[:0]
+		best := -1
+		for _, h := range c.accepted {
+			if h.norm.start < c.next {
+				continue
+			}
+			keep = append(keep, h)
+			if best < 0 || c.better(h, keep[best]) {
+				best = len(keep) - 1
+			}
+		}
+		c.accepted = keep
+		if best < 0 || !final && !c.settled(keep[best]) {
+			return
+		}
+		h := keep[best]
+		c.accepted = append(keep[:best], keep[best+1:]...)
+		c.next = h.norm.end + 1
+		c.emit(h)
+	}
+}
+
+// better 按匹配模式判断 a 是否应先于 b 选取（规则同 selectHits）
+func (c *streamCore) better(a, b coreHit) bool {
+	switch c.mode {
+	case MatchLeftmostLongest:
+		return a.norm.start < b.norm.start || a.norm.start == b.norm.start && a.norm.end > b.norm.end
+	case MatchLeftmostFirst:
+		if a.norm.start != b.norm.start {
+			return a.norm.start < b.norm.start
+		}
+		if a.seq != b.seq {
+			return a.seq < b.seq
+		}
+		return a.norm.end > b.norm.end
+	}
+	return a.norm.end < b.norm.end || a.norm.end == b.norm.end && a.norm.start < b.norm.start
+}
+
+// settled 判断尚未判定的命中与后续命中都不会先于 h 被选取
+func (c *streamCore) settled(h coreHit) bool {
+	if c.mode == MatchNonOverlapping {
+		// 后续命中的结束位置都晚于 h
+		for _, p := range c.pending {
+			if p.norm.end <= h.norm.end {
+				return false
+			}
+		}
+		return true
+	}
+	// 后续命中最早从倒数第 maxLen-1 个规范化字符开始
+	bound := c.n - int64(c.maxLen) + 1
+	for _, p := range c.pending {
+		if p.norm.start < bound {
+			bound = p.norm.start
+		}
+	}
+	return h.norm.start < bound
+}
+
+// emit 输出一个选取的命中，超出 MaxMatches 时结束扫描
+func (c *streamCore) emit(h coreHit) {
+	if c.max > 0 && c.picked == c.max {
+		c.limit = &LimitError{Kind: LimitMatches, Max: c.max, Actual: c.max + 1}
+		c.done = true
+		return
+	}
+	c.picked++
+	c.ready = append(c.ready, h)
+}
+
+func (c *streamCore) covered(r normRange) bool {
+	for _, a := range c.allowed {
+		if a.start <= r.start && r.end <= a.end {
+			return true
+		}
+	}
+	return false
+}
+
+// safeOffset 返回一个原文偏移，此前的字节不会再被新的命中覆盖
+func (c *streamCore) safeOffset() int64 {
+	if c.done {
+		return c.pos
+	}
+	safe := c.pos
+	// 后续命中最早从倒数第 maxLen-1 个规范化字符开始
+	if keep := c.maxLen - 1; keep > 0 && len(c.window) > 0 {
+		i := len(c.window) - keep
+		if i < 0 {
+			i = 0
+		}
+		if s := c.window[i].start; s < safe {
+			safe = s
+		}
+	}
+	for _, h := range c.pending {
+		if h.match.Start < safe {
+			safe = h.match.Start
+		}
+	}
+	for _, h := range c.accepted {
+		if h.match.Start < safe {
+			safe = h.match.Start
+		}
+	}
+	return safe
+}
+
+// fill 丢弃不再需要的原文并读取下一块
+func (c *streamCore) fill() {
+	keep := c.safeOffset()
+	if c.retain < keep {
+		keep = c.retain
+	}
+	if drop := int(keep - c.rawBase); drop > 0 {
+		c.raw = c.raw[:copy(c.raw, c.raw[drop:])]
+		c.rawBase = keep
+	}
+	if cap(c.raw)-len(c.raw) < scanChunkSize {
+		grown := make([]byte, len(c.raw), 2*cap(c.raw)+scanChunkSize)
+		copy(grown, c.raw)
+		c.raw = grown
+	}
+	n, err := c.src.Read(c.raw[len(c.raw) : len(c.raw)+scanChunkSize])
+	c.raw = c.raw[:len(c.raw)+n]
+	if err != nil {
+		c.srcDone = true
+		if err != io.EOF {
+			c.err = err
+		}
+	}
+}
+
+// Scanner 流式扫描 io.Reader 中的敏感词，适合处理无法整体读入内存的日志与上传文件
+// 归一化与自动机状态跨读取分块保持，命中的偏移相对输入开头；语义与 FindAll 的逐次命中一致（含白名单与匹配模式）
+// Scanner 不可并发使用
+type Scanner struct {
+	core  *streamCore
+	match Match
+	err   error
+}
+
+// NewScanner 创建流式扫描器，使用创建时的匹配结构、归一化配置、白名单与资源限制
+// 用法与 bufio.Scanner 相同：循环调用 Scan，通过 Match 取得命中，结束后检查 Err
+// 底层过滤器不支持逐字符匹配（FilterDfa）时 Scan 返回 false，Err 返回 ErrStreamingUnsupported
+func (m *Manager) NewScanner(r io.Reader) *Scanner {
+	core, err := newStreamCore(m.nf, r)
+	return &Scanner{core: core, err: err}
+}
+
+// Scan 前进到下一个命中（按结束位置排序），没有更多命中或读取出错时返回 false
+func (s *Scanner) Scan() bool {
+	if s.core == nil {
+		return false
+	}
+	for len(s.core.ready) == 0 && s.core.step() {
+	}
+	if len(s.core.ready) == 0 {
+		s.err = s.core.err
+		if s.err == nil {
+			s.err = s.core.limit
+		}
+		return false
+	}
+	s.match = s.core.ready[0].match
+	s.core.ready = s.core.ready[1:]
+	return true
+}
+
+// Match 返回最近一次 Scan 得到的命中
+func (s *Scanner) Match() Match {
+	return s.match
+}
+
+// Err 返回扫描过程中的错误（读到 io.EOF 不视为错误），达到资源限制提前结束时返回 *LimitError
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// replaceEmitSize 替换读取器累积多少字节的确定输出后再返回给调用方
+const replaceEmitSize = 4 << 10
+
+// replaceReader 边读取边替换（或删除）敏感词的 io.Reader
+type replaceReader struct {
+	core    *streamCore
+	repl    rune
+	remove  bool
+	emitted int64     // 已输出的原文偏移
+	spans   []coreHit // 尚未完全输出的命中
+	out     []byte
+	pass    bool // 达到资源限制后原样输出剩余原文
+	err     error
+}
+
+// NewReplaceReader 返回一个 io.Reader：读取 r 并输出将敏感词替换为 repl 后的文本，语义与 Replace 一致
+// 只缓冲仍可能属于后续命中的原文；底层过滤器不支持逐字符匹配时 Read 返回 ErrStreamingUnsupported
+func (m *Manager) NewReplaceReader(r io.Reader, repl rune) io.Reader {
+	core, err := newStreamCore(m.nf, r)
+	if core != nil {
+		core.retain = 0
+	}
+	return &replaceReader{core: core, repl: repl, err: err}
+}
+
+// NewRemoveReader 返回一个 io.Reader：读取 r 并输出删除敏感词后的文本，语义与 Remove 一致
+func (m *Manager) NewRemoveReader(r io.Reader) io.Reader {
+	rr := m.NewReplaceReader(r, 0).(*replaceReader)
+	rr.remove = true
+	return rr
+}
+
+func (rr *replaceReader) Read(p []byte) (int, error) {
+	for len(rr.out) == 0 {
+		if rr.err != nil {
+			return 0, rr.err
+		}
+		if rr.pass {
+			return rr.passthrough(p)
+		}
+		more := rr.core.step()
+		rr.spans = append(rr.spans, rr.core.ready...)
+		rr.core.ready = rr.core.ready[:0]
+		if safe := rr.core.safeOffset(); !more || safe-rr.emitted >= replaceEmitSize {
+			rr.emit(safe)
+		}
+		if !more {
+			switch {
+			case rr.core.err != nil:
+				rr.err = rr.core.err
+			case rr.core.limit != nil:
+				rr.pass = true
+			default:
+				rr.err = io.EOF
+			}
+		}
+	}
+	n := copy(p, rr.out)
+	rr.out = rr.out[n:]
+	return n, nil
+}
+
+// emit 输出 [emitted, to) 的原文：起始字节落在命中区间内的字符替换为 repl（或删除），其余按原始字节输出
+func (rr *replaceReader) emit(to int64) {
+	c := rr.core
+	buf := c.raw[rr.emitted-c.rawBase : to-c.rawBase]
+	for off := 0; off < len(buf); {
+		_, size := utf8.DecodeRune(buf[off:])
+		at := rr.emitted + int64(off)
+		switch {
+		case !rr.masked(at):
+			rr.out = append(rr.out, buf[off:off+size]...)
+		case !rr.remove:
+			rr.out = utf8.AppendRune(rr.out, rr.repl)
+		}
+		off += size
+	}
+	rr.emitted = to
+	c.retain = to
+	keep := rr.spans[:0]
+	for _, h := range rr.spans {
+		if h.match.End > to {
+			keep = append(keep, h)
+		}
+	}
+	rr.spans = keep
+}
+
+// passthrough 原样输出尚未处理的原文，与 Replace 对超出资源限制部分的处理一致
+func (rr *replaceReader) passthrough(p []byte) (int, error) {
+	c := rr.core
+	if buf := c.raw[c.pos-c.rawBase:]; len(buf) > 0 {
+		n := copy(p, buf)
+		c.pos += int64(n)
+		return n, nil
+	}
+	if c.srcDone {
+		rr.err = io.EOF
+		return 0, io.EOF
+	}
+	n, err := c.src.Read(p)
+	if err != nil {
+		rr.err = err
+	}
+	return n, err
+}
+
+func (rr *replaceReader) masked(at int64) bool {
+	for _, h := range rr.spans {
+		if h.match.Start <= at && at < h.match.End {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// newScannerManager 创建严格归一化配置、带白名单的流式扫描测试 Manager
+func newScannerManager(t *testing.T, ft uint32) *Manager {
+	t.Helper()
+	cfg := StrictNormalizer()
+	m := newTestManager(t, FilterOption{Type: ft, Normalizer: &cfg}, "赌博", "赌博机", "sex", "博和", "x")
+	if err := m.AddAllowWords("Essex", "sexe"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestScanner(t *testing.T) {
+	alphabet := []string{"赌", "博", "机", "和", "s", "e", "x", "E", "ｓ", " ", "​", "\xff", "。"}
+	rng := rand.New(rand.NewSource(7))
+	texts := []string{"", "赌​博 和 賭博機", "ＳＥＸ Essex sexxx", "bad\xffutf8赌博", "\xe8\xb5"}
+	for _, n := range []int{10, 100, 5000} {
+		var sb strings.Builder
+		for i := 0; i < n; i++ {
+			sb.WriteString(alphabet[rng.Intn(len(alphabet))])
+		}
+		texts = append(texts, sb.String())
+	}
+
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newScannerManager(t, ft)
+		for _, text := range texts {
+			want := m.nf.scan(text)
+			readers := map[string]func() io.Reader{
+				"whole":   func() io.Reader { return strings.NewReader(text) },
+				"onebyte": func() io.Reader { return iotest.OneByteReader(strings.NewReader(text)) },
+			}
+			for name, open := range readers {
+				sc := m.NewScanner(open())
+				var got []Match
+				for sc.Scan() {
+					got = append(got, sc.Match())
+				}
+				if err := sc.Err(); err != nil {
+					t.Fatal(err)
+				}
+				if len(got) != len(want) {
+					t.Fatalf("%s %q: %d matches, want %d", name, text, len(got), len(want))
+				}
+				for i, h := range want {
+					g := got[i]
+					if g.Word != h.word || g.Start != int64(h.start) || g.End != int64(h.end) || g.Text != text[h.start:h.end] {
+						t.Fatalf("%s %q match %d: %+v, want %+v", name, text, i, g, h)
+					}
+				}
+
+				out, err := io.ReadAll(m.NewReplaceReader(open(), '*'))
+				if err != nil || string(out) != m.Replace(text, '*') {
+					t.Fatalf("%s replace reader %q: %q, %v", name, text, out, err)
+				}
+				out, err = io.ReadAll(m.NewRemoveReader(open()))
+				if err != nil || string(out) != m.Remove(text) {
+					t.Fatalf("%s remove reader %q: %q, %v", name, text, out, err)
+				}
+			}
+		}
+	})
+}
+
+func TestScanner_LongInput(t *testing.T) {
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newScannerManager(t, ft)
+		// 超过缓冲区大小的输入：偏移相对输入开头
+		long := io.MultiReader(strings.NewReader(strings.Repeat("正常文本", 100000)), strings.NewReader("赌博"))
+		sc := m.NewScanner(long)
+		if !sc.Scan() || sc.Match().Start != 1200000 || sc.Match().Text != "赌博" || sc.Scan() {
+			t.Fatalf("long input match: %+v", sc.Match())
+		}
+	})
+}
+
+func TestScanner_ReadError(t *testing.T) {
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newScannerManager(t, ft)
+		sc := m.NewScanner(iotest.ErrReader(io.ErrUnexpectedEOF))
+		if sc.Scan() || !errors.Is(sc.Err(), io.ErrUnexpectedEOF) {
+			t.Fatalf("read error: %v", sc.Err())
+		}
+	})
+}
+
+func TestScanner_Unsupported(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterDfa}, "赌博")
+	sc := m.NewScanner(strings.NewReader("赌博"))
+	if sc.Scan() || !errors.Is(sc.Err(), ErrStreamingUnsupported) {
+		t.Fatalf("dfa scanner: %v", sc.Err())
+	}
+	if _, err := io.ReadAll(m.NewReplaceReader(strings.NewReader("赌博"), '*')); !errors.Is(err, ErrStreamingUnsupported) {
+		t.Fatalf("dfa replace reader: %v", err)
+	}
+}
+
+func TestScanner_ModeAndLimits(t *testing.T) {
+	alphabet := []string{"a", "b", "c", "d", " ", "​"}
+	rng := rand.New(rand.NewSource(11))
+	texts := []string{"abcd abc bcd", "aaaa", "abcdabcd cd"}
+	for i := 0; i < 50; i++ {
+		var sb strings.Builder
+		for j := rng.Intn(40); j > 0; j-- {
+			sb.WriteString(alphabet[rng.Intn(len(alphabet))])
+		}
+		texts = append(texts, sb.String())
+	}
+	modes := []MatchMode{MatchOverlapping, MatchLeftmostLongest, MatchLeftmostFirst, MatchNonOverlapping}
+	limits := []Limits{{}, {MaxMatches: 2}, {MaxInputRunes: 7}, {MaxMatches: 1, MaxInputRunes: 9}}
+
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "ab", "abc", "bc", "bcd", "cd", "aa", "d")
+		if err := m.AddAllowWords("dab"); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, mode := range modes {
+			m.nf.mode = mode
+			for _, lim := range limits {
+				m.SetLimits(lim)
+				for _, text := range texts {
+					_, wantErr := m.FindAllContext(context.Background(), text)
+					var want []string
+					for _, h := range m.nf.matchHits(text) {
+						want = append(want, h.word)
+					}
+					sc := m.NewScanner(iotest.OneByteReader(strings.NewReader(text)))
+					var got []string
+					for sc.Scan() {
+						got = append(got, sc.Match().Word)
+					}
+					if strings.Join(got, ",") != strings.Join(want, ",") {
+						t.Fatalf("%s %+v %q: %v, want %v", mode, lim, text, got, want)
+					}
+					var gotLimit, wantLimit *LimitError
+					errors.As(sc.Err(), &gotLimit)
+					errors.As(wantErr, &wantLimit)
+					// 同时设置两种限制时流式扫描返回先达到的限制
+					single := lim.MaxMatches == 0 || lim.MaxInputRunes == 0
+					if (gotLimit == nil) != (wantLimit == nil) || single && gotLimit != nil && gotLimit.Kind != wantLimit.Kind {
+						t.Fatalf("%s %+v %q: err %v, want %v", mode, lim, text, sc.Err(), wantErr)
+					}
+
+					out, err := io.ReadAll(m.NewReplaceReader(iotest.OneByteReader(strings.NewReader(text)), '*'))
+					if err != nil || string(out) != m.Replace(text, '*') {
+						t.Fatalf("%s %+v replace reader %q: %q, %v", mode, lim, text, out, err)
+					}
+				}
+			}
+		}
+	})
+}
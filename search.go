@@ -0,0 +1,117 @@
+package go_sensitive_word
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/store"
+)
+
+// WordOrder 词库检索结果的排序方式
+type WordOrder int
+
+const (
+	WordAsc  WordOrder = iota // 按词升序（默认）
+	WordDesc                  // 按词降序
+)
+
+// 默认与最大分页大小
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 1000
+)
+
+// WordQuery 词库检索条件，各条件之间为"且"的关系
+type WordQuery struct {
+	Prefix   string    // 词前缀（按 Manager 的归一化配置归一化后匹配）
+	Contains string    // 词包含的子串（按 Manager 的归一化配置归一化后匹配）
+	Source   string    // 词所属的来源
+	Order    WordOrder // 排序方式
+	Cursor   string    // 分页游标，为空表示第一页，取上一页返回的 NextCursor
+	Limit    int       // 每页条数，<=0 时为 50，最大 1000
+}
+
+// WordPage 词库检索的一页结果
+type WordPage struct {
+	Entries    []DictEntry // 本页词条（含来源、元数据、原始写法）
+	Total      int         // 符合条件的词总数
+	NextCursor string      // 下一页游标，为空表示没有更多结果
+}
+
+// SearchWords 按前缀、子串、来源检索词库，支持排序与基于游标的分页
+// 游标记录上一页最后一个词，翻页期间词库增删不会导致结果重复或遗漏；
+// 检索基于词库本身，新增的词写入后立即可见（无需等待后台同步到过滤器）；
+// 前缀检索只遍历词库有序索引中的前缀区间，每页的计数、过滤与词条取自同一时刻的词库
+func (m *Manager) SearchWords(query WordQuery) (*WordPage, error) {
+	if m.Store == nil {
+		return nil, errors.New("store is nil")
+	}
+	after, err := decodeCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	cfg := m.Normalizer()
+	prefix, contains := query.Prefix, query.Contains
+	if prefix != "" {
+		prefix = NormalizeWord(prefix, cfg)
+	}
+	if contains != "" {
+		contains = NormalizeWord(contains, cfg)
+	}
+
+	var match func(string, []string) bool
+	if contains != "" || query.Source != "" {
+		match = func(word string, sources []string) bool {
+			return strings.Contains(word, contains) && (query.Source == "" || containsSource(sources, query.Source))
+		}
+	}
+	res := m.Store.SearchEntries(store.WordSearch{
+		Prefix: prefix,
+		Match:  match,
+		After:  after,
+		Desc:   query.Order == WordDesc,
+		Limit:  limit,
+	})
+	page := &WordPage{Total: res.Total, Entries: res.Entries}
+	if page.Entries == nil {
+		page.Entries = []DictEntry{}
+	}
+	if res.More {
+		page.NextCursor = encodeCursor(page.Entries[len(page.Entries)-1].Word)
+	}
+	return page, nil
+}
+
+func containsSource(sources []string, source string) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeCursor 将上一页最后一个词编码为不透明的游标
+func encodeCursor(word string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(word))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("invalid cursor %q: %w", cursor, err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,129 @@
+package go_sensitive_word
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// newSearchManager 创建带来源的检索测试词库
+func newSearchManager(t *testing.T, ft uint32) *Manager {
+	t.Helper()
+	m := newTestManager(t, FilterOption{Type: ft})
+	if err := m.AddWordsWithSource([]string{"bad", "badge", "badly", "bandit"}, "en"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AddWordsWithSource([]string{"abad", "坏人"}, "custom"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSearchWords(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newSearchManager(t, ft)
+		var got []string
+		cursor := ""
+		for {
+			page, err := m.SearchWords(WordQuery{Prefix: "BA", Limit: 2, Cursor: cursor})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.Total != 4 {
+				t.Fatalf("total: %d", page.Total)
+			}
+			for _, e := range page.Entries {
+				got = append(got, e.Word)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		if want := []string{"bad", "badge", "badly", "bandit"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("prefix pages: %v", got)
+		}
+	})
+}
+
+func TestSearchWords_ContainsAndSource(t *testing.T) {
+	m := newSearchManager(t, FilterAC)
+	page, err := m.SearchWords(WordQuery{Contains: "bad", Source: "en", Order: WordDesc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Entries) != 3 || page.Entries[0].Word != "badly" || page.Entries[0].Sources[0] != "en" {
+		t.Fatalf("contains/source: %+v", page.Entries)
+	}
+}
+
+func TestSearchWords_InvalidCursor(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC})
+	if _, err := m.SearchWords(WordQuery{Cursor: "!!"}); err == nil {
+		t.Fatal("expect invalid cursor error")
+	}
+}
+
+func TestSearchWords_ImmediatelyVisible(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterDfa})
+	// 不等待后台同步：检索基于词库，写入后立即可见
+	if err := m.AddWords([]string{"bad", "badge"}); err != nil {
+		t.Fatal(err)
+	}
+	page, err := m.SearchWords(WordQuery{Prefix: "bad"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 2 {
+		t.Fatalf("prefix search before sync: %+v", page.Entries)
+	}
+	if err := m.DelWord("badge"); err != nil {
+		t.Fatal(err)
+	}
+	if page, _ := m.SearchWords(WordQuery{Prefix: "bad"}); page.Total != 1 {
+		t.Fatalf("deleted word still listed: %+v", page.Entries)
+	}
+}
+
+func TestSearchWords_IndexChurn(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC})
+	// 逐词写入与删除跨过索引的归并与压缩阈值，检索结果始终与词库一致
+	want := make(map[string]bool)
+	for i := 0; i < 3000; i++ {
+		word := fmt.Sprintf("w%04d", i%1500)
+		if i%3 == 2 {
+			if err := m.DelWord(word); err != nil {
+				t.Fatal(err)
+			}
+			delete(want, word)
+		} else {
+			if err := m.AddWord(word); err != nil {
+				t.Fatal(err)
+			}
+			want[word] = true
+		}
+		if i%250 != 0 {
+			continue
+		}
+		page, err := m.SearchWords(WordQuery{Prefix: "w01", Limit: maxSearchLimit})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expect []string
+		for w := range want {
+			if strings.HasPrefix(w, "w01") {
+				expect = append(expect, w)
+			}
+		}
+		sort.Strings(expect)
+		var got []string
+		for _, e := range page.Entries {
+			got = append(got, e.Word)
+		}
+		if page.Total != len(expect) || !reflect.DeepEqual(got, expect) {
+			t.Fatalf("step %d: got %d words (total %d), want %d", i, len(got), page.Total, len(expect))
+		}
+	}
+}
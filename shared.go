@@ -0,0 +1,133 @@
+package go_sensitive_word
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+)
+
+// sharedAutomaton 进程内共享的已编译自动机及其引用计数
+type sharedAutomaton struct {
+	compiled filter.Compiled
+	refs     int
+}
+
+var (
+	sharedMu       sync.Mutex
+	sharedAutomata = make(map[string]*sharedAutomaton)
+)
+
+// SharedAutomatonStat 共享自动机的统计信息
+type SharedAutomatonStat struct {
+	Key   string // 内容哈希（过滤器类型 + 归一化配置 + 词表）
+	Refs  int    // 引用该自动机的 Manager 数
+	Words int    // 包含的词数
+}
+
+// SharedAutomatonStats 获取当前进程内全部共享自动机的统计信息（按 Key 排序）
+func SharedAutomatonStats() []SharedAutomatonStat {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	stats := make([]SharedAutomatonStat, 0, len(sharedAutomata))
+	for key, s := range sharedAutomata {
+		stats = append(stats, SharedAutomatonStat{Key: key, Refs: s.refs, Words: s.compiled.WordCount()})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Key < stats[j].Key })
+	return stats
+}
+
+// acquireShared 获取指定内容哈希的共享自动机（不存在时编译），返回释放函数（可重复调用）
+func acquireShared(key string, compile func() filter.Compiled) (filter.Compiled, func()) {
+	sharedMu.Lock()
+	s, ok := sharedAutomata[key]
+	if !ok {
+		s = &sharedAutomaton{compiled: compile()}
+		sharedAutomata[key] = s
+	}
+	s.refs++
+	sharedMu.Unlock()
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			sharedMu.Lock()
+			defer sharedMu.Unlock()
+			if s.refs--; s.refs == 0 && sharedAutomata[key] == s {
+				delete(sharedAutomata, key)
+			}
+		})
+	}
+	return s.compiled, release
+}
+
+// sharedKey 计算共享自动机的内容哈希，words 需已排序去重
+func sharedKey(inner filter.Filter, cfg NormalizerConfig, words []string) (string, error) {
+	cfgJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%T\n%s\n", inner, cfgJSON)
+	for _, word := range words {
+		h.Write([]byte(word))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// LoadDictShared 加载内置词库内容，并与进程内其他 Manager 共享同一个已编译的自动机
+// 共享自动机按内容哈希（过滤器类型、归一化配置、词表）去重并引用计数，
+// 多个 Manager 加载相同词库时只占用一份内存；Manager 首次修改词库时才复制私有副本，
+// 关闭 Manager 或分叉时释放引用
+// 注意：仅能在词库为空时调用；过滤器不支持共享时按普通方式加载
+func (m *Manager) LoadDictShared(contents ...string) error {
+	if m.Store == nil {
+		return errors.New("store is nil")
+	}
+	m.restoreMu.Lock()
+	defer m.restoreMu.Unlock()
+	if m.Store.GetStats().TotalWords != 0 {
+		return errors.New("LoadDictShared requires an empty dictionary")
+	}
+
+	var lines []string
+	for _, content := range contents {
+		lines = append(lines, strings.Split(content, "\n")...)
+	}
+	entries := m.rawEntries(lines, "")
+	sharer, ok := m.nf.inner.(filter.Sharer)
+	if !ok {
+		return m.Store.AddEntries(entries)
+	}
+
+	set := make(map[string]struct{}, len(entries))
+	words := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if _, ok := set[entry.Word]; !ok {
+			set[entry.Word] = struct{}{}
+			words = append(words, entry.Word)
+		}
+	}
+	sort.Strings(words)
+	key, err := sharedKey(m.nf.inner, m.Normalizer(), words)
+	if err != nil {
+		return err
+	}
+	compiled, release := acquireShared(key, func() filter.Compiled { return sharer.Compile(words) })
+	if err := sharer.Attach(compiled, release); err != nil {
+		release()
+		return err
+	}
+	if m.releaseShared != nil {
+		m.releaseShared()
+	}
+	m.releaseShared = release
+	return m.Store.PreloadEntries(entries)
+}
@@ -0,0 +1,73 @@
+package go_sensitive_word
+
+import (
+	"sort"
+	"testing"
+)
+
+// sharedKeyFor 按 m 当前词库计算共享自动机的 key，断言只针对测试自己创建的共享自动机
+func sharedKeyFor(t *testing.T, m *Manager) string {
+	t.Helper()
+	var words []string
+	for _, entry := range m.Store.GetEntries() {
+		words = append(words, entry.Word)
+	}
+	sort.Strings(words)
+	key, err := sharedKey(m.nf.inner, m.Normalizer(), words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// lookupShared 按 key 查找共享自动机统计
+func lookupShared(key string) (SharedAutomatonStat, bool) {
+	for _, stat := range SharedAutomatonStats() {
+		if stat.Key == key {
+			return stat, true
+		}
+	}
+	return SharedAutomatonStat{}, false
+}
+
+func TestLoadDictShared(t *testing.T) {
+	forEachFilter(t, []uint32{FilterAC, FilterDfa}, func(t *testing.T, ft uint32) {
+		a := newTestManager(t, FilterOption{Type: ft})
+		b := newTestManager(t, FilterOption{Type: ft})
+		content := "赌博\n毒品\nBadWord\n"
+		if err := a.LoadDictShared(content); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.LoadDictShared(content); err != nil {
+			t.Fatal(err)
+		}
+		key := sharedKeyFor(t, a)
+		if stat, ok := lookupShared(key); !ok || stat.Refs != 2 || stat.Words != 3 {
+			t.Fatalf("shared stat: %+v, %v", stat, ok)
+		}
+		if !a.IsSensitive("badword") || !b.IsSensitive("毒品") || a.GetStats().TotalWords != 3 {
+			t.Fatal("shared dict not loaded")
+		}
+		if err := a.LoadDictShared(content); err == nil {
+			t.Fatal("expect error on non-empty dictionary")
+		}
+
+		// 修改后分叉为私有副本，不影响其他 Manager
+		if err := a.AddWord("诈骗"); err != nil {
+			t.Fatal(err)
+		}
+		// 等待监听协程增量写入：验证的正是增量修改时的分叉，不能用整体重建代替
+		waitForSensitive(t, a, "诈骗")
+		if b.IsSensitive("诈骗") {
+			t.Fatal("fork leaked into shared automaton")
+		}
+		if stat, ok := lookupShared(key); !ok || stat.Refs != 1 {
+			t.Fatalf("after fork: %+v, %v", stat, ok)
+		}
+
+		b.Close()
+		if stat, ok := lookupShared(key); ok {
+			t.Fatalf("after close: %+v", stat)
+		}
+	})
+}
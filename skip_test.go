@@ -0,0 +1,129 @@
+package go_sensitive_word
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// newSkipManager 创建跳过干扰字符（间隔上限为 2）的测试 Manager
+func newSkipManager(t *testing.T, ft uint32) *Manager {
+	t.Helper()
+	cfg := DefaultNormalizer()
+	cfg.SkipChars = SkipNoise
+	cfg.MaxSkipGap = 2
+	return newTestManager(t, FilterOption{Type: ft, Normalizer: &cfg}, "法轮功", "fuck")
+}
+
+// skipText 词内外都带干扰字符的测试文本及其替换结果
+const (
+	skipText    = "!!法@轮#功!! 说 f.u.c.k."
+	skipReplace = "!!*****!! 说 *******."
+)
+
+func TestSkipChars(t *testing.T) {
+	cases := []struct {
+		text string
+		want bool
+	}{
+		{"法@轮#功", true},
+		{"f.u.c.k", true},
+		{"F U C K", true},
+		{"法😀轮🔥功", true},
+		{"法，，轮功", true},
+		{"法@@@轮功", false}, // 超出 MaxSkipGap
+		{"f...u c k", false},
+		{"法 轮 大 功", false},
+	}
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newSkipManager(t, ft)
+		for _, c := range cases {
+			if got := m.IsSensitive(c.text); got != c.want {
+				t.Fatalf("IsSensitive(%q) = %v", c.text, got)
+			}
+			if got := m.IsSensitiveBytes([]byte(c.text)); got != c.want {
+				t.Fatalf("IsSensitiveBytes(%q) = %v", c.text, got)
+			}
+			if got, _ := m.IsSensitiveContext(context.Background(), c.text); got != c.want {
+				t.Fatalf("IsSensitiveContext(%q) = %v", c.text, got)
+			}
+		}
+	})
+}
+
+func TestSkipChars_FindAndReplace(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newSkipManager(t, ft)
+		// 命中片段包含词内的干扰字符，词外的干扰字符不受影响
+		if got := m.FindAll(skipText); !reflect.DeepEqual(got, []string{"法@轮#功", "f.u.c.k"}) {
+			t.Fatalf("FindAll: %v", got)
+		}
+		if got := m.Replace(skipText, '*'); got != skipReplace {
+			t.Fatalf("Replace: %q", got)
+		}
+		if got := string(m.AppendReplace(nil, []byte(skipText), '*')); got != skipReplace {
+			t.Fatalf("AppendReplace: %q", got)
+		}
+		if got, _ := m.ReplaceContext(context.Background(), skipText, '*'); got != skipReplace {
+			t.Fatalf("ReplaceContext: %q", got)
+		}
+		if got := m.Remove(skipText); got != "!!!! 说 ." {
+			t.Fatalf("Remove: %q", got)
+		}
+	})
+}
+
+func TestSkipChars_ReplaceReader(t *testing.T) {
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newSkipManager(t, ft)
+		out, err := io.ReadAll(m.NewReplaceReader(iotest.OneByteReader(strings.NewReader(skipText+" 法@@@轮功")), '*'))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != skipReplace+" 法@@@轮功" {
+			t.Fatalf("replace reader: %q", out)
+		}
+	})
+}
+
+func TestSkipChars_Default(t *testing.T) {
+	// 默认不跳过干扰字符
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "法轮功")
+	if m.IsSensitive("法@轮#功") {
+		t.Fatal("noise skipped by default")
+	}
+}
+
+func TestSkipChars_CustomRunes(t *testing.T) {
+	// 只跳过自定义字符，不限制间隔
+	cfg := DefaultNormalizer()
+	cfg.SkipRunes = "x*"
+	m := newTestManager(t, FilterOption{Type: FilterAC, Normalizer: &cfg}, "法轮功")
+	if !m.IsSensitive("法xxxxx轮*功") {
+		t.Fatal("custom skip runes ignored")
+	}
+	if m.IsSensitive("法@轮功") {
+		t.Fatal("unlisted rune skipped")
+	}
+	if got := m.Replace("法xx轮功x", '#'); got != "#####x" {
+		t.Fatalf("replace: %q", got)
+	}
+}
+
+func TestSkipChars_ZeroWidthGap(t *testing.T) {
+	cfg := DefaultNormalizer()
+	cfg.SkipChars = SkipPunct
+	cfg.RemoveZeroWidth = true
+	cfg.MaxSkipGap = 1
+	m := newTestManager(t, FilterOption{Type: FilterAC, Normalizer: &cfg}, "法轮功")
+	// 零宽字符不计入间隔，也不打断连续的干扰字符
+	if !m.IsSensitive("法​@​轮功") {
+		t.Fatal("zero-width characters counted toward MaxSkipGap")
+	}
+	if m.IsSensitive("法@​@轮功") {
+		t.Fatal("zero-width character reset the skip gap")
+	}
+}
@@ -0,0 +1,89 @@
+package go_sensitive_word
+
+import (
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/ac"
+	"github.com/LuYongwang/go-sensitive-word/internal/store"
+)
+
+// Snapshot 只读匹配快照：绑定创建时的自动机、归一化配置、白名单与词条信息，
+// 之后的词库变更、配置切换都不影响快照（资源限制与 Manager 共享，SetLimits 对快照同样生效）
+// 创建快照不复制自动机，但会复制词条信息（来源、原始写法、别名组），开销与词数成正比；不再使用时直接丢弃即可
+type Snapshot struct {
+	filter.Filter // 只读查询（FindAll、FindOne、FindAllCount、IsSensitive、Replace、Remove）
+	cfg           NormalizerConfig
+	entries       map[string]store.Entry // 创建快照时的词条（FindAllWithSource 使用）
+	version       uint64
+}
+
+// Snapshot 创建绑定当前自动机的只读匹配快照
+// 后台尚未同步到自动机的增删（批量窗口内）不包含在快照中
+func (m *Manager) Snapshot() *Snapshot {
+	m.restoreMu.Lock()
+	defer m.restoreMu.Unlock()
+	cfg := m.Normalizer()
+	var gen uint64
+	if m.nf.pf != nil {
+		gen = m.nf.pf.src.Generation()
+	}
+	var inner filter.Filter
+	if s, ok := m.nf.inner.(filter.Snapshotter); ok {
+		inner = s.Snapshot()
+	} else {
+		// 过滤器不支持快照时，按当前词库构建一个独立的自动机
+		model := ac.NewACModel()
+		if m.Store != nil {
+			model.Rebuild(m.Store.ReadString())
+		}
+		inner = model
+	}
+	nf := newNormalizedFilter(inner, cfg)
+	nf.allow.Store(m.nf.allow.Load())
+	nf.mode, nf.bound, nf.rules, nf.seqs = m.nf.mode, m.nf.bound, m.nf.rules, m.nf.seqs
+	nf.par, nf.lim = m.nf.par, m.nf.lim
+	if m.nf.pf != nil {
+		nf.pf = m.nf.pf.snapshot(inner, gen)
+	}
+
+	snap := &Snapshot{Filter: nf, cfg: cfg}
+	if m.Store != nil {
+		snap.entries, snap.version = m.Store.SnapshotEntries()
+	}
+	return snap
+}
+
+// Version 创建快照时的词库版本号（与快照的词条信息对应）
+func (s *Snapshot) Version() uint64 { return s.version }
+
+// Normalizer 快照绑定的归一化配置
+func (s *Snapshot) Normalizer() NormalizerConfig { return s.cfg }
+
+// FindAllWithSource 查找文本中所有敏感词及其来源信息
+func (s *Snapshot) FindAllWithSource(text string) []filter.MatchResult {
+	return findAllWithSource(s.Filter, text, s.resolve)
+}
+
+// FindAllCountWithSource 查找所有敏感词及其出现次数和来源信息
+func (s *Snapshot) FindAllCountWithSource(text string) map[string]filter.MatchResult {
+	return findAllCountWithSource(s.Filter, text, s.resolve)
+}
+
+// resolve 按快照的词条构造匹配结果，规则同 Manager.matchResultWith
+func (s *Snapshot) resolve(word string) filter.MatchResult {
+	variant := NormalizeWord(word, s.cfg)
+	res := filter.MatchResult{Word: word, Original: variant, Canonical: variant, Variant: variant}
+	entry, ok := s.entries[variant]
+	if !ok {
+		return res
+	}
+	if len(entry.Sources) > 0 {
+		res.Source = append([]string{}, entry.Sources...)
+	}
+	if len(entry.Originals) > 0 {
+		res.Original = entry.Originals[0].Text
+	}
+	if entry.Canonical != "" {
+		res.Canonical = entry.Canonical
+	}
+	return res
+}
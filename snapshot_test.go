@@ -0,0 +1,85 @@
+package go_sensitive_word
+
+import "testing"
+
+func TestSnapshot(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft}, "赌博", "毒品")
+		snap := m.Snapshot()
+		if err := m.DelWord("赌博"); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.AddWord("诈骗"); err != nil {
+			t.Fatal(err)
+		}
+		// 等待监听协程增量修改匹配结构：整体重建不会触及快照持有的结构，无法验证隔离
+		waitForSensitive(t, m, "诈骗")
+		waitForRemoved(t, m, "赌博")
+
+		text := "赌博和诈骗"
+		if got := snap.Replace(text, '*'); got != "**和诈骗" {
+			t.Fatalf("snapshot replace: %s", got)
+		}
+		if got := m.Replace(text, '*'); got != "赌博和**" {
+			t.Fatalf("live replace: %s", got)
+		}
+	})
+}
+
+func TestSnapshot_Version(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "赌博")
+	snap := m.Snapshot()
+	if snap.Version() != m.GetStats().Version {
+		t.Fatalf("snapshot version %d, live %d", snap.Version(), m.GetStats().Version)
+	}
+	if err := m.AddWord("诈骗"); err != nil {
+		t.Fatal(err)
+	}
+	if snap.Version() >= m.GetStats().Version {
+		t.Fatalf("snapshot version %d, live %d", snap.Version(), m.GetStats().Version)
+	}
+}
+
+func TestSnapshot_LimitsAndPrefilter(t *testing.T) {
+	forEachFilter(t, allFilters, func(t *testing.T, ft uint32) {
+		m := newTestManager(t, FilterOption{Type: ft, Prefilter: true}, "赌博", "毒品")
+		snap := m.Snapshot()
+		if err := m.DelWord("赌博"); err != nil {
+			t.Fatal(err)
+		}
+		syncTestFilter(t, m)
+		// 快照的预过滤器按快照的自动机构建，不会因词库变更漏判
+		if !snap.IsSensitive("赌博") {
+			t.Fatal("snapshot prefilter rejected a word in the snapshot")
+		}
+
+		m.SetLimits(Limits{MaxMatches: 1})
+		if got := snap.FindAllCount("赌博赌博毒品"); got["赌博"] != 1 || len(got) != 1 {
+			t.Fatalf("snapshot ignores limits: %v", got)
+		}
+	})
+}
+
+func TestSnapshot_EntriesFrozen(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC})
+	if err := m.AddWordsWithSource([]string{"赌博"}, "base"); err != nil {
+		t.Fatal(err)
+	}
+	syncTestFilter(t, m)
+
+	snap := m.Snapshot()
+	if err := m.AddWordsWithSource([]string{"赌博"}, "extra"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.DelWord("赌博"); err != nil {
+		t.Fatal(err)
+	}
+	// 词库变更后快照的来源信息不变
+	results := snap.FindAllWithSource("赌博")
+	if len(results) != 1 || len(results[0].Source) != 1 || results[0].Source[0] != "base" {
+		t.Fatalf("snapshot results: %+v", results)
+	}
+	if got := snap.FindAllCountWithSource("赌博赌博")["赌博"]; len(got.Source) != 1 || got.Source[0] != "base" {
+		t.Fatalf("snapshot count results: %+v", got)
+	}
+}
@@ -0,0 +1,64 @@
+package go_sensitive_word
+
+import (
+	"reflect"
+	"testing"
+)
+
+// newStreamingManager 创建严格归一化配置下的单遍匹配测试 Manager
+func newStreamingManager(t *testing.T, ft uint32) *Manager {
+	t.Helper()
+	cfg := StrictNormalizer()
+	m := newTestManager(t, FilterOption{Type: ft, Normalizer: &cfg}, "赌博", "赌博机", "sex", "fuck", "博和")
+	if err := m.AddAllowWords("Essex"); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// 单遍匹配（AC、双数组 AC）与先归一化再匹配（DFA）的结果一致
+func TestStreamingScan(t *testing.T) {
+	texts := []string{
+		"赌​博 和 賭博機",
+		"ＳＥＸ Essex sexxx ssseeexxx",
+		"fuuuck 和 f​u​ck",
+		"他在玩赌博机和赌博赌博",
+		"bad\xffutf8赌博",
+		"无敏感词",
+		"",
+	}
+	ref := newStreamingManager(t, FilterDfa)
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newStreamingManager(t, ft)
+		for _, text := range texts {
+			if got, want := m.FindAll(text), ref.FindAll(text); !reflect.DeepEqual(got, want) {
+				t.Fatalf("find all %q: %q, want %q", text, got, want)
+			}
+			if got, want := m.FindAllCount(text), ref.FindAllCount(text); !reflect.DeepEqual(got, want) {
+				t.Fatalf("find all count %q: %v, want %v", text, got, want)
+			}
+			if got, want := m.FindOne(text), ref.FindOne(text); got != want {
+				t.Fatalf("find one %q: %q, want %q", text, got, want)
+			}
+			if got, want := m.IsSensitive(text), ref.IsSensitive(text); got != want {
+				t.Fatalf("is sensitive %q: %v, want %v", text, got, want)
+			}
+			if got, want := m.Replace(text, '*'), ref.Replace(text, '*'); got != want {
+				t.Fatalf("replace %q: %q, want %q", text, got, want)
+			}
+			if got, want := m.Remove(text), ref.Remove(text); got != want {
+				t.Fatalf("remove %q: %q, want %q", text, got, want)
+			}
+		}
+	})
+}
+
+func TestStreamingScan_OriginalText(t *testing.T) {
+	forEachFilter(t, streamFilters, func(t *testing.T, ft uint32) {
+		m := newStreamingManager(t, ft)
+		// 原文片段包含被剔除的零宽字符
+		if got := m.FindOne("x赌​博x"); got != "赌​博" {
+			t.Fatalf("find one: %q", got)
+		}
+	})
+}
@@ -0,0 +1,277 @@
+package go_sensitive_word
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/LuYongwang/go-sensitive-word/internal/filter"
+	"github.com/LuYongwang/go-sensitive-word/internal/filter/ac"
+)
+
+// Tenant 租户（命名空间）：在 Manager 的基础词库之上叠加租户自己的词、
+// 屏蔽的基础词与白名单。查询时基础词库的自动机与租户的小自动机分别匹配后合并，
+// 不复制基础词库；基础词库的增删与白名单对所有租户实时生效
+type Tenant struct {
+	filter.Filter // 租户视角的敏感词匹配（FindAll、Replace 等）
+	name          string
+	base          *Manager
+	tf            *tenantFilter
+	nf            *normalizedFilter // 持有租户白名单，归一化配置与基础词库共享
+	mu            sync.Mutex        // 串行化租户词与白名单的修改
+}
+
+// tenantOverlay 租户叠加层（写时复制，整体切换）
+type tenantOverlay struct {
+	words   []string            // 租户新增的词（归一化后，按字典序）
+	matcher *ac.ACModel         // 租户新增词的匹配自动机
+	removed map[string]struct{} // 租户屏蔽的基础词（归一化后）
+}
+
+func newTenantOverlay(words []string, removed map[string]struct{}) *tenantOverlay {
+	sort.Strings(words)
+	o := &tenantOverlay{words: words, removed: removed}
+	if len(words) > 0 {
+		o.matcher = ac.NewACModel()
+		o.matcher.Rebuild(words)
+	}
+	return o
+}
+
+// tenantFilter 在规范化文本上合并基础词库与租户叠加层的命中区间
+type tenantFilter struct {
+	base    *normalizedFilter
+	overlay atomic.Pointer[tenantOverlay]
+}
+
+// FindAllRanges 返回基础词库（剔除屏蔽词与基础白名单覆盖的命中）与租户新增词的全部命中区间
+// 结果按结束位置排序，同一结束位置时较长的词在前
+func (tf *tenantFilter) FindAllRanges(text string) []filter.Range {
+	overlay := tf.overlay.Load()
+	rText := []rune(text)
+	ranges := tf.base.findRanges(text, rText)
+	if len(overlay.removed) > 0 {
+		kept := ranges[:0:0]
+		for _, r := range ranges {
+			if _, ok := overlay.removed[string(rText[r.Start:r.End+1])]; !ok {
+				kept = append(kept, r)
+			}
+		}
+		ranges = kept
+	}
+	if overlay.matcher != nil {
+		ranges = append(ranges, overlay.matcher.FindAllRanges(text)...)
+	}
+	ranges = tf.base.allow.Load().filter(text, ranges)
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].End != ranges[j].End {
+			return ranges[i].End < ranges[j].End
+		}
+		return ranges[i].Start < ranges[j].Start
+	})
+	res := ranges[:0]
+	for i, r := range ranges {
+		if i > 0 && r == ranges[i-1] {
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func (tf *tenantFilter) FindAll(text string) []string {
+	rText := []rune(text)
+	seen := make(map[string]struct{})
+	res := make([]string, 0)
+	for _, r := range tf.FindAllRanges(text) {
+		word := string(rText[r.Start : r.End+1])
+		if _, ok := seen[word]; ok {
+			continue
+		}
+		seen[word] = struct{}{}
+		res = append(res, word)
+	}
+	return res
+}
+
+func (tf *tenantFilter) FindAllCount(text string) map[string]int {
+	rText := []rune(text)
+	res := make(map[string]int)
+	for _, r := range tf.FindAllRanges(text) {
+		res[string(rText[r.Start:r.End+1])]++
+	}
+	return res
+}
+
+func (tf *tenantFilter) FindOne(text string) string {
+	ranges := tf.FindAllRanges(text)
+	if len(ranges) == 0 {
+		return ""
+	}
+	return string([]rune(text)[ranges[0].Start : ranges[0].End+1])
+}
+
+func (tf *tenantFilter) IsSensitive(text string) bool { return len(tf.FindAllRanges(text)) > 0 }
+
+func (tf *tenantFilter) Replace(text string, repl rune) string {
+	rText := []rune(text)
+	for _, r := range tf.FindAllRanges(text) {
+		for k := r.Start; k <= r.End; k++ {
+			rText[k] = repl
+		}
+	}
+	return string(rText)
+}
+
+func (tf *tenantFilter) Remove(text string) string {
+	rText := []rune(text)
+	del := make([]bool, len(rText))
+	for _, r := range tf.FindAllRanges(text) {
+		for k := r.Start; k <= r.End; k++ {
+			del[k] = true
+		}
+	}
+	out := make([]rune, 0, len(rText))
+	for i, r := range rText {
+		if !del[i] {
+			out = append(out, r)
+		}
+	}
+	return string(out)
+}
+
+// Tenant 获取指定名称的租户，不存在时创建一个空的租户
+// 同名租户返回同一个实例
+func (m *Manager) Tenant(name string) *Tenant {
+	m.tenantMu.Lock()
+	defer m.tenantMu.Unlock()
+	if t, ok := m.tenants[name]; ok {
+		return t
+	}
+	tf := &tenantFilter{base: m.nf}
+	tf.overlay.Store(newTenantOverlay(nil, nil))
+	nf := newNormalizedFilterShared(tf, m.nf.cfg)
+	nf.lim = m.nf.lim
+	nf.mode, nf.bound, nf.rules, nf.seqs = m.nf.mode, m.nf.bound, m.nf.rules, m.nf.seqs
+	t := &Tenant{Filter: nf, name: name, base: m, tf: tf, nf: nf}
+	if m.tenants == nil {
+		m.tenants = make(map[string]*Tenant)
+	}
+	m.tenants[name] = t
+	return t
+}
+
+// DropTenant 删除租户及其叠加层
+func (m *Manager) DropTenant(name string) {
+	m.tenantMu.Lock()
+	defer m.tenantMu.Unlock()
+	delete(m.tenants, name)
+}
+
+// Tenants 获取全部租户名称（按字典序）
+func (m *Manager) Tenants() []string {
+	m.tenantMu.Lock()
+	defer m.tenantMu.Unlock()
+	names := make([]string, 0, len(m.tenants))
+	for name := range m.tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Name 租户名称
+func (t *Tenant) Name() string { return t.name }
+
+// AddWord 为租户添加敏感词（仅对该租户生效），同时取消对同名基础词的屏蔽
+// 注意：词会按基础词库的归一化配置归一化
+func (t *Tenant) AddWord(words ...string) error {
+	return t.update(func(added, removed map[string]struct{}, word string) {
+		added[word] = struct{}{}
+		delete(removed, word)
+	}, words)
+}
+
+// DelWord 删除租户的敏感词，同名的基础词也会对该租户屏蔽
+func (t *Tenant) DelWord(words ...string) error {
+	return t.update(func(added, removed map[string]struct{}, word string) {
+		delete(added, word)
+		removed[word] = struct{}{}
+	}, words)
+}
+
+// RestoreBaseWord 取消对基础词的屏蔽，使其重新对该租户生效
+func (t *Tenant) RestoreBaseWord(words ...string) error {
+	return t.update(func(_, removed map[string]struct{}, word string) {
+		delete(removed, word)
+	}, words)
+}
+
+// update 归一化词后修改租户叠加层并整体切换
+func (t *Tenant) update(fn func(added, removed map[string]struct{}, word string), words []string) error {
+	if t.base.nf == nil {
+		return errors.New("filter is nil")
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cur := t.tf.overlay.Load()
+	added := make(map[string]struct{}, len(cur.words))
+	for _, w := range cur.words {
+		added[w] = struct{}{}
+	}
+	removed := make(map[string]struct{}, len(cur.removed))
+	for w := range cur.removed {
+		removed[w] = struct{}{}
+	}
+	cfg := t.nf.config()
+	for _, word := range words {
+		if normalized := NormalizeWord(word, cfg); normalized != "" {
+			fn(added, removed, normalized)
+		}
+	}
+	list := make([]string, 0, len(added))
+	for w := range added {
+		list = append(list, w)
+	}
+	t.tf.overlay.Store(newTenantOverlay(list, removed))
+	return nil
+}
+
+// GetWords 获取租户新增的词（归一化后，按字典序）
+func (t *Tenant) GetWords() []string {
+	return append([]string{}, t.tf.overlay.Load().words...)
+}
+
+// GetRemovedWords 获取租户屏蔽的基础词（归一化后，按字典序）
+func (t *Tenant) GetRemovedWords() []string {
+	removed := t.tf.overlay.Load().removed
+	list := make([]string, 0, len(removed))
+	for w := range removed {
+		list = append(list, w)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// AddAllowWords 为租户添加白名单短语（与基础词库的白名单同时生效）
+func (t *Tenant) AddAllowWords(words ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nf.addAllowWords(words)
+	return nil
+}
+
+// DelAllowWords 删除租户的白名单短语
+func (t *Tenant) DelAllowWords(words ...string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nf.delAllowWords(words)
+	return nil
+}
+
+// GetAllowWords 获取租户的白名单短语（不含基础词库的白名单）
+func (t *Tenant) GetAllowWords() []string {
+	return append([]string{}, t.nf.allow.Load().words...)
+}
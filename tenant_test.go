@@ -0,0 +1,64 @@
+package go_sensitive_word
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTenant(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "赌博", "毒品")
+	app1 := m.Tenant("app1")
+	if app1 != m.Tenant("app1") {
+		t.Fatal("expect same tenant instance")
+	}
+	if err := app1.AddWord("竞品"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app1.DelWord("赌博"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app1.AddAllowWords("毒品检测"); err != nil {
+		t.Fatal(err)
+	}
+
+	text := "赌博、毒品、竞品、毒品检测"
+	if got := app1.FindAll(text); !reflect.DeepEqual(got, []string{"毒品", "竞品"}) {
+		t.Fatalf("tenant find all: %v", got)
+	}
+	if got := app1.Replace(text, '*'); got != "赌博、**、**、毒品检测" {
+		t.Fatalf("tenant replace: %s", got)
+	}
+	// 基础词库不受租户影响
+	if got := m.FindAll(text); !reflect.DeepEqual(got, []string{"赌博", "毒品"}) {
+		t.Fatalf("base find all: %v", got)
+	}
+	// 其他租户只看到基础词库
+	if got := m.Tenant("app2").FindAll(text); !reflect.DeepEqual(got, []string{"赌博", "毒品"}) {
+		t.Fatalf("app2 find all: %v", got)
+	}
+	if got := m.Tenants(); !reflect.DeepEqual(got, []string{"app1", "app2"}) {
+		t.Fatalf("tenants: %v", got)
+	}
+}
+
+func TestTenant_BaseChanges(t *testing.T) {
+	m := newTestManager(t, FilterOption{Type: FilterAC}, "赌博")
+	app1 := m.Tenant("app1")
+	if err := app1.DelWord("赌博"); err != nil {
+		t.Fatal(err)
+	}
+	// 基础词库的变更对租户实时生效
+	if err := m.AddWord("诈骗"); err != nil {
+		t.Fatal(err)
+	}
+	syncTestFilter(t, m)
+	if !app1.IsSensitive("诈骗") {
+		t.Fatal("expect base word visible to tenant")
+	}
+	if err := app1.RestoreBaseWord("赌博"); err != nil {
+		t.Fatal(err)
+	}
+	if !app1.IsSensitive("赌博") {
+		t.Fatal("expect restored base word")
+	}
+}
@@ -1,269 +1,291 @@
 package go_sensitive_word
 
 import (
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
 	"github.com/LuYongwang/go-sensitive-word/internal/filter"
 )
 
 // normalizedFilter 对底层 filter.Filter 做归一化包装：
 // - 查询时：对文本与字典均做相同归一化
-// - 返回时：基于匹配到的规范化片段在原文中定位，返回原文片段
+// - 返回时：基于匹配到的规范化区间映射回原文，返回原文片段
+// - 白名单：命中区间完全落在白名单短语内的敏感词会被忽略
 type normalizedFilter struct {
-	cfg   NormalizerConfig
+	cfg   *atomic.Pointer[NormalizerConfig] // 归一化配置（恢复备份时整体切换，租户与基础词库共享）
 	inner filter.Filter
+	allow atomic.Pointer[allowList] // 白名单（写时复制）
+	scans sync.Pool                 // 单遍匹配的临时缓冲（底层过滤器支持 filter.Streamer 时使用）
+	pf    *prefilterState           // 预过滤器（nil 表示未启用）
+	par   ParallelOptions           // 超长文本分块并发匹配配置
+	lim   *atomic.Pointer[Limits]   // 资源限制（租户与基础词库共享，nil 值表示不限制）
+	mode  MatchMode                 // 命中选取方式
+	bound BoundaryMode              // 全局词边界规则
+	rules boundaryRules             // 按词设置的边界规则来源（词条元数据，nil 表示没有）
+	seqs  wordSeqs                  // 词的加入顺序（最左优先模式使用，nil 表示没有）
 }
 
 func newNormalizedFilter(inner filter.Filter, cfg NormalizerConfig) *normalizedFilter {
-	return &normalizedFilter{cfg: cfg, inner: inner}
+	ptr := &atomic.Pointer[NormalizerConfig]{}
+	ptr.Store(&cfg)
+	return newNormalizedFilterShared(inner, ptr)
 }
 
-func (nf *normalizedFilter) FindOne(text string) string {
-	normText, idxMap := NormalizeTextWithMap(text, nf.cfg)
-	hit := nf.inner.FindOne(normText)
-	if hit == "" {
-		return ""
+// newNormalizedFilterShared 使用共享的归一化配置创建包装器
+func newNormalizedFilterShared(inner filter.Filter, cfg *atomic.Pointer[NormalizerConfig]) *normalizedFilter {
+	nf := &normalizedFilter{inner: inner, cfg: cfg, lim: &atomic.Pointer[Limits]{}}
+	nf.allow.Store(newAllowList(nil))
+	if streamer, ok := inner.(filter.Streamer); ok {
+		nf.scans.New = func() any { return &streamScan{matcher: streamer.NewMatcher()} }
+	}
+	return nf
+}
+
+// config 返回当前归一化配置
+func (nf *normalizedFilter) config() NormalizerConfig {
+	return *nf.cfg.Load()
+}
+
+// span 原文字节区间 [start, end)
+type span struct {
+	start int
+	end   int
+}
+
+// hit 一次命中：规范化后的词及其在原文中的字节区间
+type hit struct {
+	word string // 命中的规范化词
+	span        // 原文字节区间
+}
+
+// scan 对文本做归一化匹配并返回全部命中（已剔除白名单覆盖的命中），按结束位置排序
+// 输入按资源限制截断；命中数由调用方在按匹配模式选取后封顶（见 pickHits）
+// 底层过滤器支持 filter.Streamer 时单遍完成：逐字符归一化后直接送入匹配器，原文偏移随读取记录，
+// 不构造规范化文本与索引映射；否则先归一化整段文本再匹配
+func (nf *normalizedFilter) scan(text string) []hit {
+	// 超长文本跳过单遍匹配，规范化后分块并发匹配（按字节数预估，findRanges 中再按字符数确认）
+	if !nf.parallelEligible(len(text)) {
+		if sc := nf.scanString(text, false); sc != nil {
+			defer nf.release(sc)
+			return sc.result()
+		}
+	}
+
+	text, _ = nf.limitInput(text)
+	if !nf.prefilterString(text) {
+		return nil
+	}
+	normText, idxMap := NormalizeTextWithMap(text, nf.config())
+	rNorm := []rune(normText)
+	ranges := nf.findRanges(normText, rNorm)
+	if len(ranges) > 0 {
+		ranges = nf.allow.Load().filter(normText, ranges)
+	}
+	hits := nf.acceptHits(text, rangeHits(text, rNorm, idxMap, ranges))
+	nf.prefilterResult(len(hits) > 0)
+	return hits
+}
+
+// acceptHits 剔除不满足词边界规则或超出干扰字符间隔（MaxSkipGap）的命中，均按原文判断
+func (nf *normalizedFilter) acceptHits(text string, hits []hit) []hit {
+	cfg := nf.config()
+	if len(hits) == 0 || !nf.boundaryActive() && cfg.MaxSkipGap <= 0 {
+		return hits
+	}
+	res := hits[:0]
+	for _, h := range hits {
+		if !cfg.skipGapOK(text[h.start:h.end]) {
+			continue
+		}
+		if nf.bounded(h.word, runeBefore(text, h.start), runeAfter(text, h.end)) {
+			res = append(res, h)
+		}
 	}
-	// 在规范化文本中再次定位命中的首个区间
-	// 简单做法：在 normText 中查找 hit 的首次位置
-	// 注意：inner 的实现基于规范化文本，无需再次归一化
-	start := -1
-	end := -1
-	rText := []rune(normText)
-	rHit := []rune(hit)
-	for i := 0; i+len(rHit) <= len(rText); i++ {
+	return res
+}
+
+// rangeHits 将规范化区间映射为原文命中
+func rangeHits(text string, rNorm []rune, idxMap []int, ranges []filter.Range) []hit {
+	if len(ranges) == 0 {
+		return nil
+	}
+	// 原文字符序号 -> 字节偏移（无效字节与 []rune 转换一样各计为一个字符）
+	offsets := make([]int, 0, len(text)+1)
+	for i := range text {
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(text))
+	hits := make([]hit, 0, len(ranges))
+	for _, r := range ranges {
+		hits = append(hits, hit{
+			word: string(rNorm[r.Start : r.End+1]),
+			span: span{start: offsets[idxMap[r.Start]], end: offsets[idxMap[r.End]+1]},
+		})
+	}
+	return hits
+}
+
+// findRanges 在规范化文本中查找全部命中区间
+func (nf *normalizedFilter) findRanges(normText string, rNorm []rune) []filter.Range {
+	// 超长文本分块并发匹配
+	if nf.parallelEligible(len(rNorm)) {
+		pr := nf.inner.(filter.ParallelRangedFilter)
+		return pr.FindAllRangesParallel(rNorm, nf.par.Workers, nf.par.minChunk())
+	}
+	// 优先使用 FindAllRanges（如果支持）
+	if rf, ok := nf.inner.(filter.RangedFilter); ok {
+		return rf.FindAllRanges(normText)
+	}
+	// 降级到 FindAll（需要二次搜索定位）
+	var ranges []filter.Range
+	for _, h := range nf.inner.FindAll(normText) {
+		ranges = append(ranges, indexAll(rNorm, []rune(h))...)
+	}
+	return ranges
+}
+
+// parallelEligible 判断长度为 n 的文本是否走分块并发匹配（已启用、底层过滤器支持且至少可切分为两块）
+func (nf *normalizedFilter) parallelEligible(n int) bool {
+	if nf.par.chunks(n) < 2 {
+		return false
+	}
+	_, ok := nf.inner.(filter.ParallelRangedFilter)
+	return ok
+}
+
+// indexAll 返回 sub 在 runes 中的所有出现区间（允许重叠）
+func indexAll(runes, sub []rune) []filter.Range {
+	if len(sub) == 0 {
+		return nil
+	}
+	var ranges []filter.Range
+	for i := 0; i+len(sub) <= len(runes); i++ {
 		ok := true
-		for j := 0; j < len(rHit); j++ {
-			if rText[i+j] != rHit[j] {
+		for j := 0; j < len(sub); j++ {
+			if runes[i+j] != sub[j] {
 				ok = false
 				break
 			}
 		}
 		if ok {
-			start = i
-			end = i + len(rHit) - 1
-			break
+			ranges = append(ranges, filter.Range{Start: i, End: i + len(sub) - 1})
 		}
 	}
-	if start == -1 {
+	return ranges
+}
+
+func (nf *normalizedFilter) FindOne(text string) string {
+	if sc := nf.scanString(text, true); sc != nil {
+		defer nf.release(sc)
+		if len(sc.hits) == 0 {
+			return ""
+		}
+		r := sc.span(sc.hits[0])
+		return text[r.start:r.end]
+	}
+	hits := nf.scan(text)
+	if len(hits) == 0 {
 		return ""
 	}
-	// 映射回原文区间
-	origRunes := []rune(text)
-	lo := idxMap[start]
-	hi := idxMap[end]
-	return string(origRunes[lo : hi+1])
+	return text[hits[0].start:hits[0].end]
 }
 
 func (nf *normalizedFilter) FindAll(text string) []string {
-	normText, idxMap := NormalizeTextWithMap(text, nf.cfg)
-
-	// 优先使用 FindAllRanges（如果支持）
-	if rf, ok := nf.inner.(filter.RangedFilter); ok {
-		ranges := rf.FindAllRanges(normText)
-		if len(ranges) == 0 {
-			return []string{}
-		}
-		origRunes := []rune(text)
-		res := make([]string, 0, len(ranges))
-		for _, r := range ranges {
-			lo := idxMap[r.Start]
-			hi := idxMap[r.End]
-			if lo < len(origRunes) && hi < len(origRunes) {
-				res = append(res, string(origRunes[lo:hi+1]))
-			}
-		}
-		return res
-	}
+	return findAllHits(text, nf.matchHits(text))
+}
 
-	// 降级到 FindAll（需要二次搜索）
-	hits := nf.inner.FindAll(normText)
+// findAllHits 按规范化词去重，返回首次出现的原文片段
+func findAllHits(text string, hits []hit) []string {
 	if len(hits) == 0 {
-		return hits
+		return []string{}
 	}
-	// 逐个命中在原文中定位
+	seen := make(map[string]struct{}, len(hits))
 	res := make([]string, 0, len(hits))
 	for _, h := range hits {
-		rText := []rune(normText)
-		rHit := []rune(h)
-		start := -1
-		end := -1
-		for i := 0; i+len(rHit) <= len(rText); i++ {
-			ok := true
-			for j := 0; j < len(rHit); j++ {
-				if rText[i+j] != rHit[j] {
-					ok = false
-					break
-				}
-			}
-			if ok {
-				start = i
-				end = i + len(rHit) - 1
-				break
-			}
-		}
-		if start == -1 {
+		if _, ok := seen[h.word]; ok {
 			continue
 		}
-		origRunes := []rune(text)
-		lo := idxMap[start]
-		hi := idxMap[end]
-		res = append(res, string(origRunes[lo:hi+1]))
+		seen[h.word] = struct{}{}
+		res = append(res, text[h.start:h.end])
 	}
 	return res
 }
 
 func (nf *normalizedFilter) FindAllCount(text string) map[string]int {
-	normText, idxMap := NormalizeTextWithMap(text, nf.cfg)
-	hits := nf.inner.FindAll(normText)
+	return countHits(text, nf.matchHits(text))
+}
+
+// countHits 统计每个词的命中次数（命中已按匹配模式选取），key 为首次出现的原文片段
+func countHits(text string, hits []hit) map[string]int {
 	res := make(map[string]int, len(hits))
-	// 简化：基于 FindAll 的唯一集合统计位置，再次在原文映射并计数
-	rText := []rune(normText)
+	keys := make(map[string]string, len(hits))
 	for _, h := range hits {
-		rHit := []rune(h)
-		count := 0
-		for i := 0; i+len(rHit) <= len(rText); i++ {
-			ok := true
-			for j := 0; j < len(rHit); j++ {
-				if rText[i+j] != rHit[j] {
-					ok = false
-					break
-				}
-			}
-			if ok {
-				count++
-				i += len(rHit) - 1
-			}
-		}
-		if count == 0 {
-			continue
-		}
-		// 返回原文片段作为 key：取首次出现位置
-		// 定位首次
-		start := -1
-		end := -1
-		for i := 0; i+len(rHit) <= len(rText); i++ {
-			ok := true
-			for j := 0; j < len(rHit); j++ {
-				if rText[i+j] != rHit[j] {
-					ok = false
-					break
-				}
-			}
-			if ok {
-				start = i
-				end = i + len(rHit) - 1
-				break
-			}
-		}
-		if start == -1 {
-			continue
+		key, ok := keys[h.word]
+		if !ok {
+			key = text[h.start:h.end]
+			keys[h.word] = key
 		}
-		origRunes := []rune(text)
-		lo := idxMap[start]
-		hi := idxMap[end]
-		res[string(origRunes[lo:hi+1])] = count
+		res[key]++
 	}
 	return res
 }
 
 func (nf *normalizedFilter) IsSensitive(text string) bool {
-	return nf.FindOne(text) != ""
+	if sc := nf.scanString(text, true); sc != nil {
+		defer nf.release(sc)
+		return len(sc.hits) > 0
+	}
+	if nf.allow.Load().empty() && !nf.boundaryActive() && nf.config().MaxSkipGap <= 0 {
+		text, _ = nf.limitInput(text)
+		if !nf.prefilterString(text) {
+			return false
+		}
+		normText, _ := NormalizeTextWithMap(text, nf.config())
+		found := nf.inner.IsSensitive(normText)
+		nf.prefilterResult(found)
+		return found
+	}
+	return len(nf.scan(text)) > 0
 }
 
 func (nf *normalizedFilter) Replace(text string, repl rune) string {
-	normText, idxMap := NormalizeTextWithMap(text, nf.cfg)
-	rOrig := []rune(text)
-	marked := make([]bool, len(rOrig))
+	return replaceHits(text, nf.matchHits(text), repl, false)
+}
 
-	// 优先使用 FindAllRanges（如果支持）
-	if rf, ok := nf.inner.(filter.RangedFilter); ok {
-		ranges := rf.FindAllRanges(normText)
-		for _, r := range ranges {
-			lo := idxMap[r.Start]
-			hi := idxMap[r.End]
-			for k := lo; k <= hi && k < len(marked); k++ {
-				marked[k] = true
-			}
-		}
-	} else {
-		// 降级到 FindAll
-		hits := nf.inner.FindAll(normText)
-		rNorm := []rune(normText)
-		for _, h := range hits {
-			rHit := []rune(h)
-			for i := 0; i+len(rHit) <= len(rNorm); i++ {
-				ok := true
-				for j := 0; j < len(rHit); j++ {
-					if rNorm[i+j] != rHit[j] {
-						ok = false
-						break
-					}
-				}
-				if ok {
-					lo := idxMap[i]
-					hi := idxMap[i+len(rHit)-1]
-					for k := lo; k <= hi && k < len(marked); k++ {
-						marked[k] = true
-					}
-					i += len(rHit) - 1
-				}
-			}
-		}
-	}
+func (nf *normalizedFilter) Remove(text string) string {
+	return replaceHits(text, nf.matchHits(text), 0, true)
+}
 
-	for i := range marked {
-		if marked[i] {
-			rOrig[i] = repl
-		}
+// replaceHits 将命中区间内的字符替换为 repl（remove 为 true 时删除），没有命中时返回原文
+func replaceHits(text string, hits []hit, repl rune, remove bool) string {
+	if len(hits) == 0 {
+		return text
 	}
-	return string(rOrig)
+	return string(appendMarked(make([]byte, 0, len(text)), text, hits, repl, remove))
 }
 
-func (nf *normalizedFilter) Remove(text string) string {
-	normText, idxMap := NormalizeTextWithMap(text, nf.cfg)
-	rOrig := []rune(text)
-	del := make([]bool, len(rOrig))
-
-	// 优先使用 FindAllRanges（如果支持）
-	if rf, ok := nf.inner.(filter.RangedFilter); ok {
-		ranges := rf.FindAllRanges(normText)
-		for _, r := range ranges {
-			lo := idxMap[r.Start]
-			hi := idxMap[r.End]
-			for k := lo; k <= hi && k < len(del); k++ {
-				del[k] = true
-			}
-		}
-	} else {
-		// 降级到 FindAll
-		hits := nf.inner.FindAll(normText)
-		rNorm := []rune(normText)
-		for _, h := range hits {
-			rHit := []rune(h)
-			for i := 0; i+len(rHit) <= len(rNorm); i++ {
-				ok := true
-				for j := 0; j < len(rHit); j++ {
-					if rNorm[i+j] != rHit[j] {
-						ok = false
-						break
-					}
-				}
-				if ok {
-					lo := idxMap[i]
-					hi := idxMap[i+len(rHit)-1]
-					for k := lo; k <= hi && k < len(del); k++ {
-						del[k] = true
-					}
-					i += len(rHit) - 1
-				}
-			}
+// appendMarked 将 text 追加到 dst：落在命中区间内的字符替换为 repl（remove 为 true 时删除），其余按原始字节追加
+func appendMarked(dst []byte, text string, hits []hit, repl rune, remove bool) []byte {
+	// 命中按结束位置排序，起始位置无序：先按字节标记再输出
+	marked := make([]bool, len(text))
+	for _, h := range hits {
+		for i := h.start; i < h.end; i++ {
+			marked[i] = true
 		}
 	}
-
-	out := make([]rune, 0, len(rOrig))
-	for i, r := range rOrig {
-		if !del[i] {
-			out = append(out, r)
+	for i, r := range text {
+		switch {
+		case !marked[i]:
+			if r == utf8.RuneError {
+				_, size := utf8.DecodeRuneInString(text[i:])
+				dst = append(dst, text[i:i+size]...)
+			} else {
+				dst = utf8.AppendRune(dst, r)
+			}
+		case !remove:
+			dst = utf8.AppendRune(dst, repl)
 		}
 	}
-	return string(out)
+	return dst
 }